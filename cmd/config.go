@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// runConfig handles `config check`. Loading already enforced the required
+// fields and the database settings, so this checks what MustLoad cannot:
+// the GIN mode and that every configured JWT key can be read.
+func runConfig(cfg *config.Config, args []string) error {
+
+	if len(args) != 1 || args[0] != "check" {
+		return fmt.Errorf("usage: config check")
+	}
+
+	switch cfg.GinMode {
+	case gin.DebugMode, gin.ReleaseMode, gin.TestMode:
+	default:
+		return fmt.Errorf("invalid GIN_MODE: %s", cfg.GinMode)
+	}
+
+	if err := utils.InitJWT(cfg.JWT); err != nil {
+		return err
+	}
+
+	fmt.Printf("Configuration OK (env=%s, address=%s, database=%s@%s:%d/%s, signing_kid=%s, keys=%d)\n",
+		cfg.Env, cfg.Addr, cfg.Database.User, cfg.Database.Host, cfg.Database.Port, cfg.Database.Name,
+		cfg.JWT.SigningKid, len(cfg.JWT.Keys))
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"gorm.io/gorm"
+)
+
+func openDatabase(cfg *config.Config) (*gorm.DB, error) {
+
+	db, err := database.InitDB(cfg.Database)
+	if err != nil {
+		return nil, fmt.Errorf("database initialization failed: %w", err)
+	}
+
+	return db, nil
+}
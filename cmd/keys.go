@@ -0,0 +1,114 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
+)
+
+// runRotateKeys writes a new private/public key pair and prints the config
+// change needed to start signing with it. The previous key must stay listed
+// until every token it signed has expired.
+func runRotateKeys(cfg *config.Config, args []string) error {
+
+	flags := flag.NewFlagSet("rotate-keys", flag.ExitOnError)
+	algorithm := flags.String("algorithm", "RS256", "RS256 or EdDSA")
+	kid := flags.String("kid", "", "key id (default <yyyy-mm>-<algorithm>)")
+	outDir := flags.String("out", "./keys", "directory for the PEM files")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *kid == "" {
+		*kid = time.Now().UTC().Format("2006-01") + "-" + strings.ToLower(*algorithm)
+	}
+
+	for _, key := range cfg.JWT.Keys {
+		if key.Kid == *kid {
+			return fmt.Errorf("kid %q is already configured", *kid)
+		}
+	}
+
+	var private, public interface{}
+
+	switch strings.ToUpper(*algorithm) {
+	case "RS256":
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return err
+		}
+		private, public = key, &key.PublicKey
+	case "EDDSA":
+		pub, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return err
+		}
+		private, public = key, pub
+	default:
+		return fmt.Errorf("unsupported algorithm %q", *algorithm)
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*outDir, 0o700); err != nil {
+		return err
+	}
+
+	privateFile := filepath.Join(*outDir, *kid+".pem")
+	publicFile := filepath.Join(*outDir, *kid+".pub.pem")
+
+	if err := writePEM(privateFile, "PRIVATE KEY", privateDER, 0o600); err != nil {
+		return err
+	}
+	if err := writePEM(publicFile, "PUBLIC KEY", publicDER, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Printf(`Wrote %s and %s
+
+Add the key to the jwt section of the config and make it the signing key,
+keeping %q listed until its tokens have expired:
+
+  signing_kid: %q
+  keys:
+    - kid: %q
+      algorithm: %q
+      private_key_file: %q
+`, privateFile, publicFile, cfg.JWT.SigningKid, *kid, *kid, *algorithm, privateFile)
+
+	return nil
+}
+
+// writePEM refuses to overwrite an existing file so a key in use is never
+// replaced by accident.
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+
+	if err := pem.Encode(file, &pem.Block{Type: blockType, Bytes: der}); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
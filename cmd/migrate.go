@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+)
+
+// runMigrate handles `migrate up`, `migrate down [-steps n]` and
+// `migrate status [-tenant slug]`. Up also brings every tenant schema up to
+// date; down only rolls back the shared migrations.
+func runMigrate(cfg *config.Config, args []string) error {
+
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up|down|status")
+	}
+
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ExitOnError)
+	steps := flags.Int("steps", 1, "number of migrations to roll back")
+	tenant := flags.String("tenant", "", "with status, list the tenant migrations of this tenant")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := database.MigrateUp(ctx, db)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration(s) applied\n", applied)
+
+	case "down":
+		if *steps < 1 {
+			return fmt.Errorf("-steps must be at least 1")
+		}
+		rolledBack, err := database.MigrateDown(ctx, db, *steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration(s) rolled back\n", rolledBack)
+
+	case "status":
+		var report []database.MigrationStatus
+		if *tenant == "" {
+			report, err = database.GetMigrationStatus(ctx, db)
+		} else {
+			var tenantCtx context.Context
+			if tenantCtx, err = tenantContext(ctx, db, *tenant); err == nil {
+				report, err = database.GetTenantMigrationStatus(ctx, db, database.TenantSchema(tenantCtx))
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, migration := range report {
+			status, appliedAt := "pending", ""
+			if migration.Applied {
+				status = "applied"
+				appliedAt = migration.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			if migration.Modified {
+				status += " (modified)"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", migration.Version, migration.Name, status, appliedAt)
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown migrate command %q", args[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+)
+
+// runSeed upserts the seed definitions for the configured env, or the one
+// given with -env, into the default tenant or the one given with -tenant.
+func runSeed(cfg *config.Config, args []string) error {
+
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	env := flags.String("env", cfg.Env, "seed definitions to apply (dev, demo or test)")
+	dir := flags.String("dir", cfg.Seed.Dir, "directory overriding the embedded seed files")
+	tenant := flags.String("tenant", "", "slug of the tenant to seed roles and users into; default tenant when empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := tenantContext(context.Background(), db, *tenant)
+	if err != nil {
+		return err
+	}
+
+	return database.SeedDatabase(ctx, db, *env, *dir)
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"log/slog"
@@ -14,6 +16,7 @@ import (
 	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
 	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
 	"github.com/chand-magar/SolidBaseGoStructure/internal/router"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -21,43 +24,98 @@ func main() {
 
 	cfg := config.MustLoad()
 
-	slog.Info("storage initialized", slog.String("env", cfg.Env), slog.String("version", "1.0.0"))
-
-	// Environment Variables
-	dbHost := os.Getenv("_DATABASE_HOST_")
-	dbUser := os.Getenv("_DATABASE_USER_")
-	dbPass := "M@gAr!~t0rE!#2025" //os.Getenv("_DATABASE_PASSWORD_")
-	dbName := os.Getenv("_DATABASE_NAME_")
-	dbPort := os.Getenv("_DATABASE_PORT_")
+	args := flag.Args()
+	if len(args) == 0 {
+		args = []string{"serve"}
+	}
 
-	if dbPort == "" {
-		dbPort = "5433" // Default PostgreSQL port
+	var err error
+
+	switch args[0] {
+	case "serve":
+		err = runServe(cfg)
+	case "migrate":
+		err = runMigrate(cfg, args[1:])
+	case "seed":
+		err = runSeed(cfg, args[1:])
+	case "tenant":
+		err = runTenant(cfg, args[1:])
+	case "create-admin":
+		err = runCreateAdmin(cfg, args[1:])
+	case "reset-password":
+		err = runResetPassword(cfg, args[1:])
+	case "rotate-keys":
+		err = runRotateKeys(cfg, args[1:])
+	case "config":
+		err = runConfig(cfg, args[1:])
+	case "help", "-h", "--help":
+		usage()
+	default:
+		usage()
+		err = fmt.Errorf("unknown command %q", args[0])
 	}
 
-	if dbHost == "" || dbUser == "" || dbPass == "" || dbName == "" {
-		fmt.Println("Environment variables not set properly.")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
+}
 
-	// Construct DSN from environment variables
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s TimeZone=UTC",
-		dbHost, dbUser, dbPass, dbName, dbPort)
+func usage() {
+	fmt.Fprint(os.Stderr, `Usage: server [-config path] <command> [arguments]
+
+Commands:
+  serve                          start the HTTP server (default)
+  migrate up|down [-steps n]|status [-tenant slug]
+                                 apply, roll back or list schema migrations
+  tenant create -name <name> <slug>|list
+                                 provision a tenant schema or list tenants
+  seed [-tenant slug]            upsert the seed definitions for the configured env
+  create-admin [-tenant slug]    create a user with the Super Admin role
+  reset-password [-tenant slug] <username>
+                                 set a new password and revoke refresh tokens
+  rotate-keys                    generate a new JWT signing key
+  config check                   validate the configuration and keys
+`)
+}
+
+// runServe starts the HTTP server and blocks until SIGINT or SIGTERM.
+func runServe(cfg *config.Config) error {
 
-	// Initialize DB
-	db, err := database.InitDB(dsn)
+	if err := utils.InitJWT(cfg.JWT); err != nil {
+		return fmt.Errorf("JWT initialization failed: %w", err)
+	}
+
+	slog.Info("storage initialized", slog.String("env", cfg.Env), slog.String("version", "1.0.0"))
+
+	db, err := openDatabase(cfg)
 	if err != nil {
-		log.Fatalf("Database initialization failed: %v", err)
+		return err
+	}
+
+	if cfg.MigrateOnStart {
+		applied, err := database.MigrateUp(context.Background(), db)
+		if err != nil {
+			return fmt.Errorf("database migration failed: %w", err)
+		}
+		slog.Info("migrations complete", slog.Int("applied", applied))
+	}
+
+	if cfg.Seed.OnStart {
+		if err := database.SeedDatabase(context.Background(), db, cfg.Env, cfg.Seed.Dir); err != nil {
+			return fmt.Errorf("database seeding failed: %w", err)
+		}
 	}
 
 	switch cfg.GinMode {
 	case gin.DebugMode, gin.ReleaseMode, gin.TestMode:
 		gin.SetMode(cfg.GinMode)
 	default:
-		log.Fatalf("Invalid GIN_MODE: %s", cfg.GinMode)
+		return fmt.Errorf("invalid GIN_MODE: %s", cfg.GinMode)
 	}
 
 	// Initialize Gin router with DB
-	ginRouter := router.AllRouter(db)
+	ginRouter := router.AllRouter(db, cfg)
 
 	// Create http.Server with ginRouter as Handler
 	server := &http.Server{
@@ -73,7 +131,7 @@ func main() {
 
 	go func() {
 		err := server.ListenAndServe()
-		if err != nil {
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Fatal("failed to start server")
 		}
 	}()
@@ -90,4 +148,6 @@ func main() {
 	}
 
 	slog.Info("server shutdown successfully")
+
+	return nil
 }
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	repositories "github.com/chand-magar/SolidBaseGoStructure/internal/repositories"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/services"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"gorm.io/gorm"
+)
+
+// runTenant handles `tenant create -name <name> <slug>` and `tenant list`.
+func runTenant(cfg *config.Config, args []string) error {
+
+	if len(args) == 0 {
+		return fmt.Errorf("usage: tenant create|list")
+	}
+
+	flags := flag.NewFlagSet("tenant "+args[0], flag.ExitOnError)
+	name := flags.String("name", "", "display name of the tenant (required for create)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	tenantService := newTenantService(db)
+
+	switch args[0] {
+	case "create":
+		if flags.NArg() != 1 || *name == "" {
+			return fmt.Errorf("usage: tenant create -name <name> <slug>")
+		}
+
+		tenant, err := tenantService.Create(ctx, dto.TenantRequestDTO{Slug: flags.Arg(0), TenantName: *name})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created tenant %q (%s) in schema %s\n", tenant.Slug, tenant.TenantId, tenant.SchemaName)
+
+	case "list":
+		tenants, err := tenantService.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SLUG\tNAME\tSCHEMA\tSTATUS\tCREATED AT")
+		for _, tenant := range tenants {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", tenant.Slug, tenant.TenantName, tenant.SchemaName, tenant.Status,
+				tenant.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown tenant command %q", args[0])
+	}
+
+	return nil
+}
+
+func newTenantService(db *gorm.DB) interfaces.TenantService {
+	return services.NewTenantService(repositories.NewTenantRepository(db), database.NewTenantProvisioner(db))
+}
+
+// tenantContext scopes ctx to the active tenant with slug; an empty slug
+// keeps the default tenant.
+func tenantContext(ctx context.Context, db *gorm.DB, slug string) (context.Context, error) {
+
+	if slug == "" {
+		return ctx, nil
+	}
+
+	tenant, err := newTenantService(db).Resolve(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("tenant %q: %w", slug, err)
+	}
+
+	return utils.WithTenant(ctx, tenant), nil
+}
@@ -0,0 +1,103 @@
+package apperrors
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Kind classifies an error by how the client should react to it.
+type Kind int
+
+const (
+	// Internal is a failure the client cannot fix; its details are never
+	// shown to the client.
+	Internal Kind = iota
+	Validation
+	Unauthorized
+	Forbidden
+	NotFound
+	Conflict
+	// Unavailable is a temporary failure, such as the database being down
+	// or too slow; the request may succeed when retried.
+	Unavailable
+)
+
+var kindStatus = map[Kind]int{
+	Internal:     http.StatusInternalServerError,
+	Validation:   http.StatusBadRequest,
+	Unauthorized: http.StatusUnauthorized,
+	Forbidden:    http.StatusForbidden,
+	NotFound:     http.StatusNotFound,
+	Conflict:     http.StatusConflict,
+	Unavailable:  http.StatusServiceUnavailable,
+}
+
+// Status is the HTTP status code responses for the kind use.
+func (k Kind) Status() int {
+	if status, ok := kindStatus[k]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Error is a domain error. Its message is safe to show to clients; the
+// wrapped cause, such as a driver error, is only kept for logging.
+type Error struct {
+	Kind    Kind
+	Message string
+	Err     error
+}
+
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// Wrap returns a domain error with message that keeps err as its cause.
+func Wrap(kind Kind, message string, err error) *Error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// KindOf returns the kind of the first domain error in err's chain, or
+// Internal when there is none.
+func KindOf(err error) Kind {
+
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Kind
+	}
+
+	return Internal
+}
+
+// MessageOf returns the message of the first domain error in err's chain,
+// leaving out the context callers wrapped around it, or "" when there is
+// none.
+func MessageOf(err error) string {
+
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Message
+	}
+
+	return ""
+}
+
+// CauseOf returns the error wrapped by the first domain error in err's
+// chain, for logging; nil when there is none.
+func CauseOf(err error) error {
+
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Err
+	}
+
+	return nil
+}
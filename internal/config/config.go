@@ -4,32 +4,88 @@ import (
 	"flag"
 	"log"
 	"os"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type HTTPServer struct {
 	Addr string `yaml:"address" env-required:"true"`
+	// RequestTimeout is the deadline placed on each request's context and
+	// so on the queries it runs; zero disables it.
+	RequestTimeout time.Duration `yaml:"request_timeout" env:"HTTP_REQUEST_TIMEOUT" env-default:"30s"`
+}
+
+// SigningKey describes one JWT key. Asymmetric keys (RS256, EdDSA) are read
+// from PEM files; a key with only a public key file can verify but not sign.
+// HS256 keys take their secret from SecretFile or Secret.
+type SigningKey struct {
+	Kid            string `yaml:"kid"`
+	Algorithm      string `yaml:"algorithm"`
+	PrivateKeyFile string `yaml:"private_key_file"`
+	PublicKeyFile  string `yaml:"public_key_file"`
+	SecretFile     string `yaml:"secret_file"`
+	Secret         string `yaml:"secret"`
+}
+
+type JWT struct {
+	Issuer          string        `yaml:"issuer" env:"JWT_ISSUER" env-default:"solid-base-go-structure"`
+	Audience        string        `yaml:"audience" env:"JWT_AUDIENCE" env-default:"solid-base-go-structure-api"`
+	AccessTokenTTL  time.Duration `yaml:"access_token_ttl" env:"JWT_ACCESS_TOKEN_TTL" env-default:"15m"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl" env:"JWT_REFRESH_TOKEN_TTL" env-default:"720h"`
+	SigningKid      string        `yaml:"signing_kid" env:"JWT_SIGNING_KID" env-required:"true"`
+	Keys            []SigningKey  `yaml:"keys"`
+}
+
+// Seed selects the seed definitions for Env. Dir overrides the definitions
+// embedded in the binary with <dir>/<env>.yaml (or .yml/.json).
+type Seed struct {
+	OnStart bool   `yaml:"on_start" env:"SEED_ON_START" env-default:"false"`
+	Dir     string `yaml:"dir" env:"SEED_DIR"`
+}
+
+// Tenancy controls how a request is matched to a tenant: by the Header
+// value, else by the subdomain of BaseDomain in the Host, else by the tenant
+// claim of its bearer token. Requests matching none use the default tenant,
+// whose tables live in master.
+type Tenancy struct {
+	Header     string `yaml:"header" env:"TENANT_HEADER" env-default:"X-Tenant-ID"`
+	BaseDomain string `yaml:"base_domain" env:"TENANT_BASE_DOMAIN"`
 }
 
 type Config struct {
 	Env        string `yaml:"env" env:"ENV" env-required:"true" env-default:"production"`
 	GinMode    string `yaml:"GIN_MODE" env-required:"true" env:"GIN_MODE" env-default:"production"`
 	HTTPServer `yaml:"http_server"`
+	JWT        JWT      `yaml:"jwt"`
+	Database   Database `yaml:"database"`
+	// UserPurgeRetention is how long soft-deleted users are kept before
+	// they may be purged.
+	UserPurgeRetention time.Duration `yaml:"user_purge_retention" env:"USER_PURGE_RETENTION" env-default:"720h"`
+	// MigrateOnStart applies pending migrations before the server starts
+	// listening.
+	MigrateOnStart bool    `yaml:"migrate_on_start" env:"MIGRATE_ON_START" env-default:"false"`
+	Seed           Seed    `yaml:"seed"`
+	Tenancy        Tenancy `yaml:"tenancy"`
 }
 
+// configFlag is registered on the default flag set so the CLI can read its
+// subcommand from flag.Args() after MustLoad has parsed the flags.
+var configFlag = flag.String("config", "", "path to the configuration file")
+
 func MustLoad() *Config {
 
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+
 	var configPath string
 
 	configPath = os.Getenv("CONFIG_PATH")
 
 	if configPath == "" {
 
-		flags := flag.String("config", "", "path to the configuration file")
-		flag.Parse()
-
-		configPath = *flags
+		configPath = *configFlag
 
 		if configPath == "" {
 
@@ -49,5 +105,9 @@ func MustLoad() *Config {
 		log.Fatalf("can not read config file: %s", err.Error())
 	}
 
+	if err := cfg.Database.Resolve(); err != nil {
+		log.Fatalf("invalid config: %s", err.Error())
+	}
+
 	return &cfg
 }
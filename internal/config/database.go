@@ -0,0 +1,180 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// Secret is a string that never prints its value, so configs can be logged
+// or dumped with %+v without leaking credentials.
+type Secret string
+
+const redacted = "[REDACTED]"
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Database holds the PostgreSQL connection settings. The password may be
+// given inline or read from PasswordFile (Docker/Kubernetes secrets); the
+// file wins when both are set.
+type Database struct {
+	Host         string `yaml:"host" env:"_DATABASE_HOST_" env-required:"true"`
+	Port         int    `yaml:"port" env:"_DATABASE_PORT_" env-default:"5432"`
+	User         string `yaml:"user" env:"_DATABASE_USER_" env-required:"true"`
+	Password     Secret `yaml:"password" env:"_DATABASE_PASSWORD_"`
+	PasswordFile string `yaml:"password_file" env:"_DATABASE_PASSWORD_FILE_"`
+	Name         string `yaml:"name" env:"_DATABASE_NAME_" env-required:"true"`
+	SSLMode      string `yaml:"sslmode" env:"_DATABASE_SSLMODE_" env-default:"disable"`
+	SearchPath   string `yaml:"search_path" env:"_DATABASE_SEARCH_PATH_" env-default:"master,public"`
+	TimeZone     string `yaml:"timezone" env:"_DATABASE_TIMEZONE_" env-default:"UTC"`
+
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"_DATABASE_MAX_OPEN_CONNS_" env-default:"25"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"_DATABASE_MAX_IDLE_CONNS_" env-default:"5"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"_DATABASE_CONN_MAX_LIFETIME_" env-default:"30m"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"_DATABASE_CONN_MAX_IDLE_TIME_" env-default:"5m"`
+
+	// StatementTimeout is set as the server-side statement_timeout of every
+	// connection; zero leaves the server default.
+	StatementTimeout time.Duration `yaml:"statement_timeout" env:"_DATABASE_STATEMENT_TIMEOUT_" env-default:"30s"`
+	// ConnectTimeout bounds each connection attempt.
+	ConnectTimeout time.Duration `yaml:"connect_timeout" env:"_DATABASE_CONNECT_TIMEOUT_" env-default:"5s"`
+	// ConnectRetries is how many times a failed startup connection is
+	// retried, waiting ConnectBackoff and doubling it after each attempt.
+	ConnectRetries int           `yaml:"connect_retries" env:"_DATABASE_CONNECT_RETRIES_" env-default:"5"`
+	ConnectBackoff time.Duration `yaml:"connect_backoff" env:"_DATABASE_CONNECT_BACKOFF_" env-default:"500ms"`
+
+	// Replicas serve read-only queries. They share the primary's user,
+	// password, database name and pool settings.
+	Replicas []DatabaseReplica `yaml:"replicas"`
+	// ReplicaHealthInterval is how often replicas are pinged; an unhealthy
+	// replica gets no reads until a ping succeeds again.
+	ReplicaHealthInterval time.Duration `yaml:"replica_health_interval" env:"_DATABASE_REPLICA_HEALTH_INTERVAL_" env-default:"10s"`
+}
+
+type DatabaseReplica struct {
+	Host string `yaml:"host"`
+	// Port defaults to the primary's port.
+	Port int `yaml:"port"`
+}
+
+var sslModes = map[string]bool{
+	"disable": true, "allow": true, "prefer": true,
+	"require": true, "verify-ca": true, "verify-full": true,
+}
+
+// Resolve reads the password file, if any, and validates the settings.
+func (d *Database) Resolve() error {
+
+	if d.PasswordFile != "" {
+		data, err := os.ReadFile(d.PasswordFile)
+		if err != nil {
+			return fmt.Errorf("database: password_file: %w", err)
+		}
+		d.Password = Secret(strings.TrimSpace(string(data)))
+	}
+
+	if d.Password == "" {
+		return fmt.Errorf("database: password or password_file is required")
+	}
+	if d.Port < 1 || d.Port > 65535 {
+		return fmt.Errorf("database: port %d is out of range", d.Port)
+	}
+	if !sslModes[d.SSLMode] {
+		return fmt.Errorf("database: invalid sslmode %q", d.SSLMode)
+	}
+	if d.MaxOpenConns < 0 || d.MaxIdleConns < 0 {
+		return fmt.Errorf("database: pool sizes cannot be negative")
+	}
+	if d.StatementTimeout < 0 || d.ConnectTimeout < 0 || d.ConnectRetries < 0 || d.ConnectBackoff < 0 {
+		return fmt.Errorf("database: timeouts and retries cannot be negative")
+	}
+	for i, replica := range d.Replicas {
+		if replica.Host == "" {
+			return fmt.Errorf("database: replicas[%d]: host is required", i)
+		}
+		if replica.Port < 0 || replica.Port > 65535 {
+			return fmt.Errorf("database: replicas[%d]: port %d is out of range", i, replica.Port)
+		}
+	}
+	if len(d.Replicas) > 0 && d.ReplicaHealthInterval <= 0 {
+		return fmt.Errorf("database: replica_health_interval must be positive")
+	}
+	if d.MaxOpenConns > 0 && d.MaxIdleConns > d.MaxOpenConns {
+		return fmt.Errorf("database: max_idle_conns (%d) exceeds max_open_conns (%d)", d.MaxIdleConns, d.MaxOpenConns)
+	}
+
+	return nil
+}
+
+// DSN builds a libpq key/value connection string, quoting every value.
+func (d Database) DSN() string {
+
+	parts := []string{
+		"host=" + dsnValue(d.Host),
+		fmt.Sprintf("port=%d", d.Port),
+		"user=" + dsnValue(d.User),
+		"password=" + dsnValue(string(d.Password)),
+		"dbname=" + dsnValue(d.Name),
+		"sslmode=" + dsnValue(d.SSLMode),
+	}
+	if d.SearchPath != "" {
+		parts = append(parts, "search_path="+dsnValue(d.SearchPath))
+	}
+	if d.TimeZone != "" {
+		parts = append(parts, "TimeZone="+dsnValue(d.TimeZone))
+	}
+	if d.StatementTimeout > 0 {
+		parts = append(parts, fmt.Sprintf("statement_timeout=%d", d.StatementTimeout.Milliseconds()))
+	}
+	if d.ConnectTimeout > 0 {
+		// libpq takes whole seconds; round up so a sub-second value is not 0.
+		parts = append(parts, fmt.Sprintf("connect_timeout=%d", int((d.ConnectTimeout+time.Second-1)/time.Second)))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// ForReplica returns these settings pointed at replica.
+func (d Database) ForReplica(replica DatabaseReplica) Database {
+	d.Host = replica.Host
+	if replica.Port != 0 {
+		d.Port = replica.Port
+	}
+	d.Replicas = nil
+	return d
+}
+
+// LogValue describes the connection without the password.
+func (d Database) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("host", d.Host),
+		slog.Int("port", d.Port),
+		slog.String("user", d.User),
+		slog.String("password", d.Password.String()),
+		slog.String("name", d.Name),
+		slog.String("sslmode", d.SSLMode),
+		slog.String("search_path", d.SearchPath),
+		slog.Int("replicas", len(d.Replicas)),
+	)
+}
+
+func dsnValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
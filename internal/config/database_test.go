@@ -0,0 +1,90 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestDatabaseDSN(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		database Database
+		want     string
+	}{
+		{
+			name:     "plain values",
+			database: Database{Host: "db", Port: 5432, User: "app", Password: "secret", Name: "solid", SSLMode: "disable"},
+			want:     `host='db' port=5432 user='app' password='secret' dbname='solid' sslmode='disable'`,
+		},
+		{
+			name:     "quotes, backslashes and spaces are escaped",
+			database: Database{Host: "db", Port: 5432, User: "app", Password: `it's a \secret`, Name: "solid", SSLMode: "disable"},
+			want:     `host='db' port=5432 user='app' password='it\'s a \\secret' dbname='solid' sslmode='disable'`,
+		},
+		{
+			name:     "empty password stays quoted",
+			database: Database{Host: "db", Port: 5432, User: "app", Name: "solid", SSLMode: "disable"},
+			want:     `host='db' port=5432 user='app' password='' dbname='solid' sslmode='disable'`,
+		},
+		{
+			name: "optional settings",
+			database: Database{Host: "db", Port: 6432, User: "app", Password: "secret", Name: "solid", SSLMode: "require",
+				SearchPath: "master,public", TimeZone: "UTC", StatementTimeout: 1500 * time.Millisecond, ConnectTimeout: 2500 * time.Millisecond},
+			want: `host='db' port=6432 user='app' password='secret' dbname='solid' sslmode='require' ` +
+				`search_path='master,public' TimeZone='UTC' statement_timeout=1500 connect_timeout=3`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.database.DSN(); got != tt.want {
+				t.Errorf("DSN() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// The driver must read every value back exactly, whatever characters the
+// settings contain.
+func TestDatabaseDSNParsesBack(t *testing.T) {
+
+	passwords := []string{
+		"secret",
+		"",
+		"with space",
+		`quote'd`,
+		`back\slash`,
+		`\'`,
+		`key=value host=evil`,
+		"trailing\\",
+	}
+
+	for _, password := range passwords {
+		t.Run(password, func(t *testing.T) {
+
+			database := Database{Host: "db.internal", Port: 5432, User: "o'brien", Password: Secret(password),
+				Name: "solid base", SSLMode: "disable", SearchPath: "tenant_a, master", ConnectTimeout: 200 * time.Millisecond}
+
+			parsed, err := pgconn.ParseConfig(database.DSN())
+			if err != nil {
+				t.Fatalf("ParseConfig() error = %v", err)
+			}
+
+			if parsed.Password != password {
+				t.Errorf("password = %q, want %q", parsed.Password, password)
+			}
+			if parsed.Host != "db.internal" || parsed.User != "o'brien" || parsed.Database != "solid base" {
+				t.Errorf("host, user, dbname = %q, %q, %q", parsed.Host, parsed.User, parsed.Database)
+			}
+			if got := parsed.RuntimeParams["search_path"]; got != "tenant_a, master" {
+				t.Errorf("search_path = %q", got)
+			}
+			if parsed.ConnectTimeout != time.Second {
+				t.Errorf("connect timeout = %v, want a sub-second value rounded up to 1s", parsed.ConnectTimeout)
+			}
+		})
+	}
+}
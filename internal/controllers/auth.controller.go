@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AuthController struct {
+	Service interfaces.AuthService
+}
+
+func NewAuthController(service interfaces.AuthService) *AuthController {
+	return &AuthController{Service: service}
+}
+
+func (ctrl *AuthController) Login(c *gin.Context) {
+
+	var request dto.LoginRequestDTO
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.Validate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	request.UserAgent = c.Request.UserAgent()
+	request.IpAddress = c.ClientIP()
+
+	token, err := ctrl.Service.Login(c.Request.Context(), request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":        true,
+		"access_token":  token.AccessToken,
+		"refresh_token": token.RefreshToken,
+		"token_type":    token.TokenType,
+		"expires_in":    token.ExpiresIn,
+	})
+}
+
+func (ctrl *AuthController) Refresh(c *gin.Context) {
+
+	var request dto.RefreshRequestDTO
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.Validate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	request.UserAgent = c.Request.UserAgent()
+	request.IpAddress = c.ClientIP()
+
+	token, err := ctrl.Service.Refresh(c.Request.Context(), request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":        true,
+		"access_token":  token.AccessToken,
+		"refresh_token": token.RefreshToken,
+		"token_type":    token.TokenType,
+		"expires_in":    token.ExpiresIn,
+	})
+}
+
+func (ctrl *AuthController) Logout(c *gin.Context) {
+
+	var request dto.RefreshRequestDTO
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.Validate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := ctrl.Service.Logout(c.Request.Context(), request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "message": "Logged out successfully"})
+}
+
+// JWKS publishes the public verification keys so other services can verify
+// access tokens locally.
+func (ctrl *AuthController) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, utils.PublicJWKS())
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/middleware"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type MenuController struct {
+	Service interfaces.MenuService
+}
+
+func NewMenuController(service interfaces.MenuService) *MenuController {
+	return &MenuController{Service: service}
+}
+
+// GetMenu returns the caller's navigation menu with an ETag so clients can
+// revalidate with If-None-Match instead of downloading it again.
+func (ctrl *MenuController) GetMenu(c *gin.Context) {
+
+	principal, ok := middleware.GetPrincipal(c)
+	if !ok {
+		utils.AbortWithProblem(c, http.StatusUnauthorized, "Missing bearer token")
+		return
+	}
+
+	menu, err := ctrl.Service.GetMenu(c.Request.Context(), principal.RoleId)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	body, err := json.Marshal(gin.H{"status": true, "data": menu})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+	c.Header("Vary", "Authorization")
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
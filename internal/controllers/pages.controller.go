@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PageController struct {
+	Service interfaces.PageService
+}
+
+func NewPageController(service interfaces.PageService) *PageController {
+	return &PageController{Service: service}
+}
+
+func (ctrl *PageController) Create(c *gin.Context) {
+
+	sectionId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	var request dto.PageRequestDTO
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.Validate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	id, err := ctrl.Service.Create(c.Request.Context(), sectionId, request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": true, "page_id": id})
+}
+
+func (ctrl *PageController) GetBySection(c *gin.Context) {
+
+	sectionId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	pages, err := ctrl.Service.GetBySection(c.Request.Context(), sectionId)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": true,
+		"data":   pages,
+	})
+}
+
+func (ctrl *PageController) FindOne(c *gin.Context) {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	page, err := ctrl.Service.FindOne(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": true,
+		"data":   page,
+	})
+}
+
+func (ctrl *PageController) Update(c *gin.Context) {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	var request dto.PageRequestDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.ValidateUpdate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := ctrl.Service.Update(c.Request.Context(), id, request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "message": "Page updated successfully"})
+}
+
+func (ctrl *PageController) Delete(c *gin.Context) {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	if err := ctrl.Service.Delete(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "message": "Page deleted successfully"})
+}
+
+func (ctrl *PageController) Reorder(c *gin.Context) {
+
+	sectionId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	var request dto.ReorderDTO
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.Validate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := ctrl.Service.Reorder(c.Request.Context(), sectionId, request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "message": "Pages reordered successfully"})
+}
+
+func (ctrl *PageController) Move(c *gin.Context) {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	var request dto.PageMoveDTO
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.Validate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := ctrl.Service.Move(c.Request.Context(), id, request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "message": "Page moved successfully"})
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// maxPageSize caps the size parameter of every listing, offset or keyset.
+const maxPageSize = 100
+
+// paginationParams reads page, size, search, status, sort_by and order from
+// the query string, falling back to sane defaults for invalid values and
+// capping size at maxPageSize.
+func paginationParams(c *gin.Context, defaultSortBy string) dto.PaginationParams {
+	pageStr := c.DefaultQuery("page", "1")
+	sizeStr := c.DefaultQuery("size", "10")
+
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size < 1 {
+		size = 10
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+
+	order := strings.ToUpper(c.DefaultQuery("order", "ASC"))
+	if order != "ASC" && order != "DESC" {
+		order = "ASC"
+	}
+
+	return dto.PaginationParams{
+		Page:   page,
+		Size:   size,
+		Search: c.DefaultQuery("search", ""),
+		Status: c.DefaultQuery("status", ""),
+		SortBy: c.DefaultQuery("sort_by", defaultSortBy),
+		Order:  order,
+	}
+}
+
+// sortParams parses sort=field,-field into sort fields, a leading "-"
+// sorting that field descending. Every field must be a key of allowed.
+// Without sort, the sort_by and order parameters are used as one field.
+func sortParams(c *gin.Context, allowed map[string]string, params dto.PaginationParams) ([]dto.SortField, error) {
+
+	raw := strings.TrimSpace(c.Query("sort"))
+	if raw == "" {
+		if _, ok := allowed[params.SortBy]; !ok {
+			return nil, fmt.Errorf("sort_by: unknown field %q", params.SortBy)
+		}
+		return []dto.SortField{{Field: params.SortBy, Desc: params.Order == "DESC"}}, nil
+	}
+
+	var fields []dto.SortField
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		field := strings.TrimPrefix(strings.TrimPrefix(part, "-"), "+")
+
+		if _, ok := allowed[field]; !ok {
+			return nil, fmt.Errorf("sort: unknown field %q", field)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("sort: field %q is listed twice", field)
+		}
+		seen[field] = true
+
+		fields = append(fields, dto.SortField{Field: field, Desc: desc})
+	}
+
+	return fields, nil
+}
+
+// dateRangeParams reads an inclusive YYYY-MM-DD range from the from and to
+// query parameters; either end may be omitted.
+func dateRangeParams(c *gin.Context, from, to string) (*time.Time, *time.Time, error) {
+
+	start, err := dateParam(c, from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	end, err := dateParam(c, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if start != nil && end != nil && end.Before(*start) {
+		return nil, nil, fmt.Errorf("%s must not be after %s", from, to)
+	}
+
+	return start, end, nil
+}
+
+func dateParam(c *gin.Context, name string) (*time.Time, error) {
+
+	raw := strings.TrimSpace(c.Query(name))
+	if raw == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: expected a date as YYYY-MM-DD", name)
+	}
+
+	return &date, nil
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RoleController struct {
+	Service interfaces.RoleService
+}
+
+func NewRoleController(service interfaces.RoleService) *RoleController {
+	return &RoleController{Service: service}
+}
+
+func (ctrl *RoleController) Create(c *gin.Context) {
+
+	var request dto.RoleRequestDTO
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.Validate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	id, err := ctrl.Service.Create(c.Request.Context(), request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": true, "role_id": id})
+}
+
+func (ctrl *RoleController) GetAll(c *gin.Context) {
+
+	params := paginationParams(c, "role_name")
+
+	roles, err := ctrl.Service.GetAll(c.Request.Context(), params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":        true,
+		"data":          roles.Items,
+		"total_records": roles.TotalRecords,
+		"total_pages":   roles.TotalPages,
+	})
+}
+
+func (ctrl *RoleController) FindOne(c *gin.Context) {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	role, err := ctrl.Service.FindOne(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": true,
+		"data":   role,
+	})
+}
+
+func (ctrl *RoleController) Update(c *gin.Context) {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	var request dto.RoleRequestDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.ValidateUpdate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := ctrl.Service.Update(c.Request.Context(), id, request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "message": "Role updated successfully"})
+}
+
+func (ctrl *RoleController) Clone(c *gin.Context) {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	var request dto.RoleCloneDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.Validate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	newId, err := ctrl.Service.Clone(c.Request.Context(), id, request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": true, "role_id": newId})
+}
+
+func (ctrl *RoleController) UpdateStatus(c *gin.Context) {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	var request dto.RoleStatusDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.Validate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := ctrl.Service.UpdateStatus(c.Request.Context(), id, request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "message": "Role status updated successfully"})
+}
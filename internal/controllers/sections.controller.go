@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SectionController struct {
+	Service interfaces.SectionService
+}
+
+func NewSectionController(service interfaces.SectionService) *SectionController {
+	return &SectionController{Service: service}
+}
+
+func (ctrl *SectionController) Create(c *gin.Context) {
+
+	var request dto.SectionRequestDTO
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.Validate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	id, err := ctrl.Service.Create(c.Request.Context(), request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": true, "section_id": id})
+}
+
+func (ctrl *SectionController) GetAll(c *gin.Context) {
+
+	params := paginationParams(c, "section_order")
+
+	sections, err := ctrl.Service.GetAll(c.Request.Context(), params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":        true,
+		"data":          sections.Items,
+		"total_records": sections.TotalRecords,
+		"total_pages":   sections.TotalPages,
+	})
+}
+
+func (ctrl *SectionController) FindOne(c *gin.Context) {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	section, err := ctrl.Service.FindOne(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": true,
+		"data":   section,
+	})
+}
+
+func (ctrl *SectionController) Update(c *gin.Context) {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	var request dto.SectionRequestDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.ValidateUpdate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := ctrl.Service.Update(c.Request.Context(), id, request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "message": "Section updated successfully"})
+}
+
+func (ctrl *SectionController) Delete(c *gin.Context) {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	if err := ctrl.Service.Delete(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "message": "Section deleted successfully"})
+}
+
+func (ctrl *SectionController) Reorder(c *gin.Context) {
+
+	var request dto.ReorderDTO
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.Validate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := ctrl.Service.Reorder(c.Request.Context(), request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "message": "Sections reordered successfully"})
+}
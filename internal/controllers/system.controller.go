@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/gin-gonic/gin"
+)
+
+type SystemController struct {
+	Service interfaces.SystemService
+}
+
+func NewSystemController(service interfaces.SystemService) *SystemController {
+	return &SystemController{Service: service}
+}
+
+// DatabaseStats answers 503 when the database cannot be reached. It sits
+// behind authentication and permission checks that query the database, so
+// probes should use Ready instead.
+func (ctrl *SystemController) DatabaseStats(c *gin.Context) {
+
+	stats, err := ctrl.Service.DatabaseStats(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	status := http.StatusOK
+	if !stats.Healthy {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, gin.H{"status": stats.Healthy, "data": stats})
+}
+
+// Ready is the unauthenticated readiness probe: 200 while the primary
+// answers a ping, 503 otherwise, without any pool details.
+func (ctrl *SystemController) Ready(c *gin.Context) {
+
+	stats, err := ctrl.Service.DatabaseStats(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	status := http.StatusOK
+	if !stats.Healthy {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, gin.H{"status": stats.Healthy})
+}
@@ -3,15 +3,13 @@ package controller
 import (
 	"fmt"
 	"net/http"
-	"strconv"
+	"regexp"
 	"strings"
-	"time"
 
 	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
 	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
 	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
 
@@ -28,25 +26,18 @@ func (ctrl *UserController) Create(c *gin.Context) {
 	var request dto.RequestDTO
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input", "details": err.Error()})
+		utils.AbortWithValidationProblem(c, err)
 		return
 	}
 
-	if err := validator.New().Struct(request); err != nil {
-		validationErrs := err.(validator.ValidationErrors)
-		c.JSON(http.StatusBadRequest, utils.ValidationError(validationErrs))
+	if err := utils.Validate(request); err != nil {
+		utils.AbortWithValidationProblem(c, err)
 		return
 	}
 
-	now := time.Now().UTC()
-	request.CreatedAt = now
-	request.UpdatedAt = now
-	request.CreatedBy = 1
-	request.UpdatedBy = 1
-
-	id, err := ctrl.Service.Create(request)
+	id, err := ctrl.Service.Create(c.Request.Context(), request)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user", "details": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -54,63 +45,128 @@ func (ctrl *UserController) Create(c *gin.Context) {
 }
 
 func (ctrl *UserController) GetAll(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	sizeStr := c.DefaultQuery("size", "10")
 
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1
+	params, err := userListParams(c)
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid query parameters: "+err.Error())
+		return
 	}
 
-	size, err := strconv.Atoi(sizeStr)
-	if err != nil || size < 1 {
-		size = 10
-	}
+	if params.UseCursor {
+		users, page, err := ctrl.Service.GetPage(c.Request.Context(), params)
+		if err != nil {
+			c.Error(err)
+			return
+		}
 
-	search := c.DefaultQuery("search", "")
-	status := c.DefaultQuery("status", "")
-	sortBy := c.DefaultQuery("sort_by", "user_full_name")
-	order := strings.ToUpper(c.DefaultQuery("order", "ASC"))
-	if order != "ASC" && order != "DESC" {
-		order = "ASC"
-	}
+		response := gin.H{"status": true, "data": users, "next_cursor": page.NextCursor, "prev_cursor": page.PrevCursor}
+		if page.TotalRecords != nil {
+			response["total_records"] = *page.TotalRecords
+			response["total_estimated"] = page.TotalEstimated
+		}
 
-	params := dto.PaginationParams{
-		Page:   page,
-		Size:   size,
-		Search: search,
-		Status: status,
-		SortBy: sortBy,
-		Order:  order,
+		c.JSON(http.StatusOK, response)
+		return
 	}
 
-	users, totalRecords, totalPages, err := ctrl.Service.GetAll(params)
+	users, err := ctrl.Service.GetAll(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"status":        true,
-		"data":          users,
-		"total_records": totalRecords,
-		"total_pages":   totalPages,
+		"data":          users.Items,
+		"total_records": users.TotalRecords,
+		"total_pages":   users.TotalPages,
 	})
 }
 
+// mobileFilterPattern keeps the mobile_no filter to digits, so it cannot
+// carry LIKE wildcards.
+var mobileFilterPattern = regexp.MustCompile(`^\+?[0-9]{1,15}$`)
+
+// userListParams reads the pagination, sort and filter parameters of the
+// user listing: role_id, gender, mobile_no (partial match) and the
+// created_from/created_to and dob_from/dob_to date ranges. pagination=cursor
+// or a cursor switches to keyset pagination, where count=exact|estimate|none
+// (default none) controls the total.
+func userListParams(c *gin.Context) (dto.PaginationParams, error) {
+
+	params := paginationParams(c, "user_full_name")
+
+	sort, err := sortParams(c, dto.UserSortFields, params)
+	if err != nil {
+		return params, err
+	}
+	params.Sort = sort
+
+	if raw := strings.TrimSpace(c.Query("role_id")); raw != "" {
+		roleId, err := uuid.Parse(raw)
+		if err != nil {
+			return params, fmt.Errorf("role_id: invalid UUID")
+		}
+		params.RoleId = &roleId
+	}
+
+	params.Gender = strings.TrimSpace(c.Query("gender"))
+
+	if mobile := strings.TrimSpace(c.Query("mobile_no")); mobile != "" {
+		if !mobileFilterPattern.MatchString(mobile) {
+			return params, fmt.Errorf("mobile_no: only digits and a leading + are allowed")
+		}
+		params.MobileNo = mobile
+	}
+
+	if params.CreatedFrom, params.CreatedTo, err = dateRangeParams(c, "created_from", "created_to"); err != nil {
+		return params, err
+	}
+	if params.DobFrom, params.DobTo, err = dateRangeParams(c, "dob_from", "dob_to"); err != nil {
+		return params, err
+	}
+
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := utils.DecodeCursor(raw)
+		if err != nil {
+			return params, err
+		}
+		// A cursor only marks a position within the order it was issued for.
+		if cursor.Sort != dto.SortSpec(params.Sort) {
+			return params, fmt.Errorf("cursor was issued for sort %q", cursor.Sort)
+		}
+		params.Cursor = cursor
+		params.UseCursor = true
+	} else if mode := c.Query("pagination"); mode != "" && mode != "offset" {
+		if mode != "cursor" {
+			return params, fmt.Errorf("pagination: expected offset or cursor")
+		}
+		params.UseCursor = true
+	}
+
+	params.Count = c.DefaultQuery("count", dto.CountNone)
+	switch params.Count {
+	case dto.CountExact, dto.CountEstimate, dto.CountNone:
+	default:
+		return params, fmt.Errorf("count: expected exact, estimate or none")
+	}
+
+	return params, nil
+}
+
 func (ctrl *UserController) FindOne(c *gin.Context) {
 
 	idParam := c.Param("id")
 	id, err := uuid.Parse(idParam)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID"})
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
 		return
 	}
 
-	user, err := ctrl.Service.FindOne(id)
+	user, err := ctrl.Service.FindOne(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		c.Error(err)
 		return
 	}
 
@@ -126,20 +182,68 @@ func (ctrl *UserController) Update(c *gin.Context) {
 	id, err := uuid.Parse(idParam)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid UUID"})
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
 		return
 	}
 
 	var data dto.RequestDTO
 	if err := c.ShouldBindJSON(&data); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid request: %s", err.Error())})
+		utils.AbortWithValidationProblem(c, err)
+		return
+	}
+
+	if err := utils.ValidateUpdate(data); err != nil {
+		utils.AbortWithValidationProblem(c, err)
 		return
 	}
 
-	if err := ctrl.Service.Update(id, data); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := ctrl.Service.Update(c.Request.Context(), id, data); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"status": true, "message": "User updated successfully"})
 }
+
+func (ctrl *UserController) Delete(c *gin.Context) {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	if err := ctrl.Service.Delete(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "message": "User deleted successfully"})
+}
+
+func (ctrl *UserController) Restore(c *gin.Context) {
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.AbortWithProblem(c, http.StatusBadRequest, "Invalid UUID")
+		return
+	}
+
+	if err := ctrl.Service.Restore(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "message": "User restored successfully"})
+}
+
+func (ctrl *UserController) Purge(c *gin.Context) {
+
+	purged, err := ctrl.Service.Purge(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": true, "purged": purged})
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/apperrors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// keyColumns reads the column list out of a constraint violation detail,
+// e.g. `Key (role_name)=(admin) already exists.`; expression indexes do not
+// match and are reported without naming columns.
+var keyColumns = regexp.MustCompile(`^Key \(([a-z0-9_, ]+)\)=`)
+
+// RegisterErrorCallbacks translates the error of every query run through db
+// with TranslateError, so repositories return domain errors without
+// inspecting driver errors themselves.
+func RegisterErrorCallbacks(db *gorm.DB) error {
+
+	callbacks := db.Callback()
+
+	return errors.Join(
+		callbacks.Create().After("*").Register("errors:translate", translateStatementError),
+		callbacks.Query().After("*").Register("errors:translate", translateStatementError),
+		callbacks.Update().After("*").Register("errors:translate", translateStatementError),
+		callbacks.Delete().After("*").Register("errors:translate", translateStatementError),
+		callbacks.Row().After("*").Register("errors:translate", translateStatementError),
+		callbacks.Raw().After("*").Register("errors:translate", translateStatementError),
+	)
+}
+
+func translateStatementError(db *gorm.DB) {
+	if db.Error != nil {
+		db.Error = TranslateError(db.Error)
+	}
+}
+
+// TranslateError maps Postgres and connection errors to domain errors:
+// constraint violations become Conflict or Validation, timeouts and lost
+// connections become Unavailable. Other errors, including those of a request
+// the client canceled, are returned unchanged.
+func TranslateError(err error) error {
+
+	if err == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	var domainErr *apperrors.Error
+	if errors.As(err, &domainErr) {
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return translatePgError(pgErr)
+	}
+
+	// pgx reports a canceled context as a timeout too, so it is checked
+	// first.
+	var connectErr *pgconn.ConnectError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return err
+	case errors.Is(err, context.DeadlineExceeded):
+		return apperrors.Wrap(apperrors.Unavailable, "database did not respond in time", err)
+	case errors.As(err, &connectErr), errors.Is(err, driver.ErrBadConn), pgconn.Timeout(err):
+		return apperrors.Wrap(apperrors.Unavailable, "database is unavailable", err)
+	}
+
+	return err
+}
+
+func translatePgError(pgErr *pgconn.PgError) error {
+
+	switch pgErr.Code {
+	case "23505": // unique_violation
+		if columns := violatedColumns(pgErr); columns != "" {
+			return apperrors.Wrap(apperrors.Conflict, columns+" is already in use", pgErr)
+		}
+		return apperrors.Wrap(apperrors.Conflict, "record already exists", pgErr)
+
+	case "23503": // foreign_key_violation
+		// The same code covers inserting a dangling reference and deleting
+		// a row that is still referenced.
+		if strings.HasPrefix(pgErr.Message, "update or delete") {
+			return apperrors.Wrap(apperrors.Conflict, "record is still referenced by other records", pgErr)
+		}
+		if columns := violatedColumns(pgErr); columns != "" {
+			return apperrors.Wrap(apperrors.Validation, columns+" references a record that does not exist", pgErr)
+		}
+		return apperrors.Wrap(apperrors.Validation, "referenced record does not exist", pgErr)
+
+	case "23502": // not_null_violation
+		return apperrors.Wrap(apperrors.Validation, fmt.Sprintf("%s is required", pgErr.ColumnName), pgErr)
+
+	case "23514", "23P01": // check_violation, exclusion_violation
+		return apperrors.Wrap(apperrors.Validation, "value violates a data constraint", pgErr)
+
+	case "22001": // string_data_right_truncation
+		return apperrors.Wrap(apperrors.Validation, "value is too long", pgErr)
+
+	case "22003", "22007", "22008", "22P02": // out of range, bad datetime, bad text representation
+		return apperrors.Wrap(apperrors.Validation, "value has an invalid format", pgErr)
+
+	case "40001", "40P01": // serialization_failure, deadlock_detected
+		return apperrors.Wrap(apperrors.Unavailable, "request conflicted with a concurrent update, please retry", pgErr)
+
+	case "57014": // query_canceled, e.g. by statement_timeout
+		return apperrors.Wrap(apperrors.Unavailable, "database did not respond in time", pgErr)
+
+	case "57P01", "57P02", "57P03": // admin_shutdown, crash_shutdown, cannot_connect_now
+		return apperrors.Wrap(apperrors.Unavailable, "database is unavailable", pgErr)
+	}
+
+	// Class 08 is connection exceptions, class 53 insufficient resources.
+	if strings.HasPrefix(pgErr.Code, "08") || strings.HasPrefix(pgErr.Code, "53") {
+		return apperrors.Wrap(apperrors.Unavailable, "database is unavailable", pgErr)
+	}
+
+	return pgErr
+}
+
+func violatedColumns(pgErr *pgconn.PgError) string {
+
+	match := keyColumns.FindStringSubmatch(pgErr.Detail)
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
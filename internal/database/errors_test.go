@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/apperrors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+func TestTranslatePgError(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		err     *pgconn.PgError
+		kind    apperrors.Kind
+		message string
+	}{
+		{
+			name:    "unique violation names its columns",
+			err:     &pgconn.PgError{Code: "23505", Detail: "Key (role_name)=(admin) already exists."},
+			kind:    apperrors.Conflict,
+			message: "role_name is already in use",
+		},
+		{
+			name:    "unique violation on several columns",
+			err:     &pgconn.PgError{Code: "23505", Detail: "Key (section_no, page_path)=(1, /a) already exists."},
+			kind:    apperrors.Conflict,
+			message: "section_no, page_path is already in use",
+		},
+		{
+			name:    "unique violation on an expression index",
+			err:     &pgconn.PgError{Code: "23505", Detail: "Key (lower(username::text))=(bob) already exists."},
+			kind:    apperrors.Conflict,
+			message: "record already exists",
+		},
+		{
+			name:    "dangling reference",
+			err:     &pgconn.PgError{Code: "23503", Message: `insert or update on table "users" violates foreign key constraint`, Detail: `Key (role_no)=(9) is not present in table "roles".`},
+			kind:    apperrors.Validation,
+			message: "role_no references a record that does not exist",
+		},
+		{
+			name:    "dangling reference without detail",
+			err:     &pgconn.PgError{Code: "23503", Message: `insert or update on table "users" violates foreign key constraint`},
+			kind:    apperrors.Validation,
+			message: "referenced record does not exist",
+		},
+		{
+			name:    "deleting a referenced row",
+			err:     &pgconn.PgError{Code: "23503", Message: `update or delete on table "sections" violates foreign key constraint`, Detail: `Key (section_no)=(1) is still referenced from table "pages".`},
+			kind:    apperrors.Conflict,
+			message: "record is still referenced by other records",
+		},
+		{
+			name:    "not null violation",
+			err:     &pgconn.PgError{Code: "23502", ColumnName: "schema_name"},
+			kind:    apperrors.Validation,
+			message: "schema_name is required",
+		},
+		{"check violation", &pgconn.PgError{Code: "23514"}, apperrors.Validation, "value violates a data constraint"},
+		{"exclusion violation", &pgconn.PgError{Code: "23P01"}, apperrors.Validation, "value violates a data constraint"},
+		{"value too long", &pgconn.PgError{Code: "22001"}, apperrors.Validation, "value is too long"},
+		{"invalid text representation", &pgconn.PgError{Code: "22P02"}, apperrors.Validation, "value has an invalid format"},
+		{"numeric out of range", &pgconn.PgError{Code: "22003"}, apperrors.Validation, "value has an invalid format"},
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, apperrors.Unavailable, "request conflicted with a concurrent update, please retry"},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, apperrors.Unavailable, "request conflicted with a concurrent update, please retry"},
+		{"statement timeout", &pgconn.PgError{Code: "57014"}, apperrors.Unavailable, "database did not respond in time"},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01"}, apperrors.Unavailable, "database is unavailable"},
+		{"connection failure", &pgconn.PgError{Code: "08006"}, apperrors.Unavailable, "database is unavailable"},
+		{"too many connections", &pgconn.PgError{Code: "53300"}, apperrors.Unavailable, "database is unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			err := TranslateError(fmt.Errorf("failed to insert: %w", tt.err))
+
+			if kind := apperrors.KindOf(err); kind != tt.kind {
+				t.Errorf("KindOf() = %v, want %v", kind, tt.kind)
+			}
+			if message := apperrors.MessageOf(err); message != tt.message {
+				t.Errorf("MessageOf() = %q, want %q", message, tt.message)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("translated error does not wrap the driver error")
+			}
+		})
+	}
+}
+
+func TestTranslateErrorKeepsOtherErrors(t *testing.T) {
+
+	undefinedTable := &pgconn.PgError{Code: "42P01", Message: `relation "master.nope" does not exist`}
+	domainErr := apperrors.New(apperrors.NotFound, "role not found")
+	plain := errors.New("boom")
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"nil", nil},
+		{"record not found", gorm.ErrRecordNotFound},
+		{"domain error", domainErr},
+		{"wrapped domain error", fmt.Errorf("lookup: %w", domainErr)},
+		{"unmapped postgres error", undefinedTable},
+		{"other error", plain},
+		{"canceled", context.Canceled},
+		{"wrapped cancel", fmt.Errorf("query: %w", context.Canceled)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TranslateError(tt.err); got != tt.err {
+				t.Errorf("TranslateError() = %v, want %v unchanged", got, tt.err)
+			}
+		})
+	}
+}
+
+func TestTranslateErrorConnectionFailures(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		err     error
+		message string
+	}{
+		{"connect error", &pgconn.ConnectError{}, "database is unavailable"},
+		{"bad connection", fmt.Errorf("query: %w", driver.ErrBadConn), "database is unavailable"},
+		{"deadline exceeded", fmt.Errorf("query: %w", context.DeadlineExceeded), "database did not respond in time"},
+		{"deadline exceeded on the connection", &net.OpError{Op: "read", Err: context.DeadlineExceeded}, "database did not respond in time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			err := TranslateError(tt.err)
+
+			if kind := apperrors.KindOf(err); kind != apperrors.Unavailable {
+				t.Errorf("KindOf() = %v, want Unavailable", kind)
+			}
+			if message := apperrors.MessageOf(err); message != tt.message {
+				t.Errorf("MessageOf() = %q, want %q", message, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS master.refresh_tokens;
+DROP TABLE IF EXISTS master.pages;
+DROP TABLE IF EXISTS master.sections;
+DROP TABLE IF EXISTS master.user_credentials;
+DROP TABLE IF EXISTS master.users;
+DROP TABLE IF EXISTS master.roles;
+DROP TYPE IF EXISTS status_enum;
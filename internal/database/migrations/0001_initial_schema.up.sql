@@ -0,0 +1,165 @@
+-- Baseline schema. Every statement is guarded so databases created by the
+-- old AutoMigrate-based setup are adopted without changes.
+
+CREATE SCHEMA IF NOT EXISTS master;
+
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'status_enum') THEN
+		CREATE TYPE status_enum AS ENUM ('A', 'I', 'D');
+	END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS master.roles (
+	role_no      smallserial PRIMARY KEY,
+	role_id      uuid,
+	role_name    varchar(65),
+	role_details jsonb DEFAULT '[]',
+	status       status_enum DEFAULT 'A',
+	created_at   timestamptz DEFAULT NULL,
+	created_by   bigint DEFAULT NULL,
+	updated_at   timestamptz DEFAULT NULL,
+	updated_by   bigint DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_master_roles_role_id ON master.roles (role_id);
+CREATE INDEX IF NOT EXISTS idx_master_roles_status ON master.roles (status);
+CREATE INDEX IF NOT EXISTS idx_master_roles_created_at ON master.roles (created_at);
+CREATE INDEX IF NOT EXISTS idx_master_roles_created_by ON master.roles (created_by);
+CREATE INDEX IF NOT EXISTS idx_master_roles_updated_at ON master.roles (updated_at);
+CREATE INDEX IF NOT EXISTS idx_master_roles_updated_by ON master.roles (updated_by);
+
+CREATE TABLE IF NOT EXISTS master.users (
+	profile_no     bigserial PRIMARY KEY,
+	profile_id     uuid,
+	role_no        bigint,
+	user_full_name varchar(65),
+	email_id       varchar(65),
+	gender         varchar(65) DEFAULT NULL,
+	dob            date DEFAULT NULL,
+	mobile_no      varchar(15) DEFAULT NULL,
+	address        jsonb DEFAULT '{}',
+	x_api_key      varchar(55) DEFAULT NULL,
+	secret_key     varchar(55) DEFAULT NULL,
+	status         status_enum DEFAULT 'A',
+	created_at     timestamptz DEFAULT NULL,
+	created_by     bigint DEFAULT NULL,
+	updated_at     timestamptz DEFAULT NULL,
+	updated_by     bigint DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_master_users_profile_id ON master.users (profile_id);
+CREATE INDEX IF NOT EXISTS idx_master_users_role_no ON master.users (role_no);
+CREATE INDEX IF NOT EXISTS idx_master_users_status ON master.users (status);
+CREATE INDEX IF NOT EXISTS idx_master_users_created_at ON master.users (created_at);
+CREATE INDEX IF NOT EXISTS idx_master_users_created_by ON master.users (created_by);
+CREATE INDEX IF NOT EXISTS idx_master_users_updated_at ON master.users (updated_at);
+CREATE INDEX IF NOT EXISTS idx_master_users_updated_by ON master.users (updated_by);
+
+CREATE TABLE IF NOT EXISTS master.user_credentials (
+	credential_no bigserial PRIMARY KEY,
+	credential_id uuid,
+	profile_no    bigint,
+	username      varchar(65),
+	password      varchar(255),
+	status        status_enum DEFAULT 'I',
+	created_at    timestamptz DEFAULT NULL,
+	created_by    bigint DEFAULT NULL,
+	updated_at    timestamptz DEFAULT NULL,
+	updated_by    bigint DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_master_user_credentials_credential_id ON master.user_credentials (credential_id);
+CREATE INDEX IF NOT EXISTS idx_master_user_credentials_profile_no ON master.user_credentials (profile_no);
+CREATE INDEX IF NOT EXISTS idx_master_user_credentials_username ON master.user_credentials (username);
+CREATE INDEX IF NOT EXISTS idx_master_user_credentials_status ON master.user_credentials (status);
+CREATE INDEX IF NOT EXISTS idx_master_user_credentials_created_at ON master.user_credentials (created_at);
+CREATE INDEX IF NOT EXISTS idx_master_user_credentials_created_by ON master.user_credentials (created_by);
+CREATE INDEX IF NOT EXISTS idx_master_user_credentials_updated_at ON master.user_credentials (updated_at);
+CREATE INDEX IF NOT EXISTS idx_master_user_credentials_updated_by ON master.user_credentials (updated_by);
+
+CREATE TABLE IF NOT EXISTS master.sections (
+	section_no    smallserial PRIMARY KEY,
+	section_id    uuid,
+	section_name  varchar(65),
+	section_path  varchar(65),
+	section_icon  varchar(65),
+	section_order smallint,
+	status        status_enum DEFAULT 'A',
+	created_at    timestamptz DEFAULT NULL,
+	created_by    bigint DEFAULT NULL,
+	updated_at    timestamptz DEFAULT NULL,
+	updated_by    bigint DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_master_sections_section_id ON master.sections (section_id);
+CREATE INDEX IF NOT EXISTS idx_master_sections_section_order ON master.sections (section_order);
+CREATE INDEX IF NOT EXISTS idx_master_sections_status ON master.sections (status);
+CREATE INDEX IF NOT EXISTS idx_master_sections_created_at ON master.sections (created_at);
+CREATE INDEX IF NOT EXISTS idx_master_sections_created_by ON master.sections (created_by);
+CREATE INDEX IF NOT EXISTS idx_master_sections_updated_at ON master.sections (updated_at);
+CREATE INDEX IF NOT EXISTS idx_master_sections_updated_by ON master.sections (updated_by);
+
+CREATE TABLE IF NOT EXISTS master.pages (
+	page_no    smallserial PRIMARY KEY,
+	page_id    uuid,
+	section_no smallint,
+	page_name  varchar(65),
+	page_path  varchar(255),
+	page_order smallint,
+	status     status_enum DEFAULT 'A',
+	created_at timestamptz DEFAULT NULL,
+	created_by bigint DEFAULT NULL,
+	updated_at timestamptz DEFAULT NULL,
+	updated_by bigint DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_master_pages_page_id ON master.pages (page_id);
+CREATE INDEX IF NOT EXISTS idx_master_pages_section_no ON master.pages (section_no);
+CREATE INDEX IF NOT EXISTS idx_master_pages_page_order ON master.pages (page_order);
+CREATE INDEX IF NOT EXISTS idx_master_pages_status ON master.pages (status);
+CREATE INDEX IF NOT EXISTS idx_master_pages_created_at ON master.pages (created_at);
+CREATE INDEX IF NOT EXISTS idx_master_pages_created_by ON master.pages (created_by);
+CREATE INDEX IF NOT EXISTS idx_master_pages_updated_at ON master.pages (updated_at);
+CREATE INDEX IF NOT EXISTS idx_master_pages_updated_by ON master.pages (updated_by);
+
+CREATE TABLE IF NOT EXISTS master.refresh_tokens (
+	token_no   bigserial PRIMARY KEY,
+	token_id   uuid,
+	family_id  uuid,
+	profile_no bigint,
+	token_hash varchar(64),
+	user_agent varchar(255) DEFAULT NULL,
+	ip_address varchar(45) DEFAULT NULL,
+	expires_at timestamptz,
+	used_at    timestamptz DEFAULT NULL,
+	revoked_at timestamptz DEFAULT NULL,
+	created_at timestamptz DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_master_refresh_tokens_token_id ON master.refresh_tokens (token_id);
+CREATE INDEX IF NOT EXISTS idx_master_refresh_tokens_family_id ON master.refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS idx_master_refresh_tokens_profile_no ON master.refresh_tokens (profile_no);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_master_refresh_tokens_token_hash ON master.refresh_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_master_refresh_tokens_expires_at ON master.refresh_tokens (expires_at);
+CREATE INDEX IF NOT EXISTS idx_master_refresh_tokens_revoked_at ON master.refresh_tokens (revoked_at);
+CREATE INDEX IF NOT EXISTS idx_master_refresh_tokens_created_at ON master.refresh_tokens (created_at);
+
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_role_no') THEN
+		ALTER TABLE master.users ADD CONSTRAINT fk_role_no FOREIGN KEY (role_no) REFERENCES master.roles(role_no) ON UPDATE SET NULL;
+	END IF;
+
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_profile_no') THEN
+		ALTER TABLE master.user_credentials ADD CONSTRAINT fk_profile_no FOREIGN KEY (profile_no) REFERENCES master.users(profile_no) ON DELETE CASCADE;
+	END IF;
+
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_section_no') THEN
+		ALTER TABLE master.pages ADD CONSTRAINT fk_section_no FOREIGN KEY (section_no) REFERENCES master.sections(section_no) ON DELETE CASCADE;
+	END IF;
+
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_refresh_profile_no') THEN
+		ALTER TABLE master.refresh_tokens ADD CONSTRAINT fk_refresh_profile_no FOREIGN KEY (profile_no) REFERENCES master.users(profile_no) ON DELETE CASCADE;
+	END IF;
+END $$;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS master.uq_master_user_credentials_username;
+DROP INDEX IF EXISTS master.uq_master_roles_role_name;
+DROP INDEX IF EXISTS master.uq_master_pages_page_path;
+DROP INDEX IF EXISTS master.uq_master_sections_section_path;
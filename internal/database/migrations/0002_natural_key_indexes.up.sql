@@ -0,0 +1,7 @@
+-- Natural keys used by the seeder's upserts. Role names are only unique
+-- among roles that are not deleted, matching the role service's check.
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_master_sections_section_path ON master.sections (section_path);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_master_pages_page_path ON master.pages (page_path);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_master_roles_role_name ON master.roles (role_name) WHERE status <> 'D';
+CREATE UNIQUE INDEX IF NOT EXISTS uq_master_user_credentials_username ON master.user_credentials (username);
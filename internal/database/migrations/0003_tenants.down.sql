@@ -0,0 +1,3 @@
+-- Tenant schemas are left in place; drop them by hand once they are no
+-- longer needed.
+DROP TABLE IF EXISTS master.tenants;
@@ -0,0 +1,19 @@
+-- Tenant registry. Every tenant's users, credentials, roles and refresh
+-- tokens live in its own schema, created from migrations/tenant; sections
+-- and pages stay shared in master.
+
+CREATE TABLE IF NOT EXISTS master.tenants (
+	tenant_no   smallserial PRIMARY KEY,
+	tenant_id   uuid,
+	slug        varchar(30) NOT NULL,
+	tenant_name varchar(65),
+	schema_name varchar(63) NOT NULL,
+	status      status_enum DEFAULT 'A',
+	created_at  timestamptz DEFAULT NULL,
+	updated_at  timestamptz DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_master_tenants_tenant_id ON master.tenants (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_master_tenants_status ON master.tenants (status);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_master_tenants_slug ON master.tenants (slug);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_master_tenants_schema_name ON master.tenants (schema_name);
@@ -0,0 +1,110 @@
+-- Per-tenant tables. {{schema}} is replaced with the tenant's schema name;
+-- for the default tenant that is master, whose tables from 0001 and 0002
+-- are adopted unchanged because every statement is guarded.
+
+CREATE TABLE IF NOT EXISTS {{schema}}.roles (
+	role_no      smallserial PRIMARY KEY,
+	role_id      uuid,
+	role_name    varchar(65),
+	role_details jsonb DEFAULT '[]',
+	status       status_enum DEFAULT 'A',
+	created_at   timestamptz DEFAULT NULL,
+	created_by   bigint DEFAULT NULL,
+	updated_at   timestamptz DEFAULT NULL,
+	updated_by   bigint DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_roles_role_id ON {{schema}}.roles (role_id);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_roles_status ON {{schema}}.roles (status);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_roles_created_at ON {{schema}}.roles (created_at);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_roles_created_by ON {{schema}}.roles (created_by);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_roles_updated_at ON {{schema}}.roles (updated_at);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_roles_updated_by ON {{schema}}.roles (updated_by);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_{{schema}}_roles_role_name ON {{schema}}.roles (role_name) WHERE status <> 'D';
+
+CREATE TABLE IF NOT EXISTS {{schema}}.users (
+	profile_no     bigserial PRIMARY KEY,
+	profile_id     uuid,
+	role_no        bigint,
+	user_full_name varchar(65),
+	email_id       varchar(65),
+	gender         varchar(65) DEFAULT NULL,
+	dob            date DEFAULT NULL,
+	mobile_no      varchar(15) DEFAULT NULL,
+	address        jsonb DEFAULT '{}',
+	x_api_key      varchar(55) DEFAULT NULL,
+	secret_key     varchar(55) DEFAULT NULL,
+	status         status_enum DEFAULT 'A',
+	created_at     timestamptz DEFAULT NULL,
+	created_by     bigint DEFAULT NULL,
+	updated_at     timestamptz DEFAULT NULL,
+	updated_by     bigint DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_profile_id ON {{schema}}.users (profile_id);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_role_no ON {{schema}}.users (role_no);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_status ON {{schema}}.users (status);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_created_at ON {{schema}}.users (created_at);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_created_by ON {{schema}}.users (created_by);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_updated_at ON {{schema}}.users (updated_at);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_updated_by ON {{schema}}.users (updated_by);
+
+CREATE TABLE IF NOT EXISTS {{schema}}.user_credentials (
+	credential_no bigserial PRIMARY KEY,
+	credential_id uuid,
+	profile_no    bigint,
+	username      varchar(65),
+	password      varchar(255),
+	status        status_enum DEFAULT 'I',
+	created_at    timestamptz DEFAULT NULL,
+	created_by    bigint DEFAULT NULL,
+	updated_at    timestamptz DEFAULT NULL,
+	updated_by    bigint DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_user_credentials_credential_id ON {{schema}}.user_credentials (credential_id);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_user_credentials_profile_no ON {{schema}}.user_credentials (profile_no);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_user_credentials_username ON {{schema}}.user_credentials (username);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_user_credentials_status ON {{schema}}.user_credentials (status);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_user_credentials_created_at ON {{schema}}.user_credentials (created_at);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_user_credentials_created_by ON {{schema}}.user_credentials (created_by);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_user_credentials_updated_at ON {{schema}}.user_credentials (updated_at);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_user_credentials_updated_by ON {{schema}}.user_credentials (updated_by);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_{{schema}}_user_credentials_username ON {{schema}}.user_credentials (username);
+
+CREATE TABLE IF NOT EXISTS {{schema}}.refresh_tokens (
+	token_no   bigserial PRIMARY KEY,
+	token_id   uuid,
+	family_id  uuid,
+	profile_no bigint,
+	token_hash varchar(64),
+	user_agent varchar(255) DEFAULT NULL,
+	ip_address varchar(45) DEFAULT NULL,
+	expires_at timestamptz,
+	used_at    timestamptz DEFAULT NULL,
+	revoked_at timestamptz DEFAULT NULL,
+	created_at timestamptz DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_refresh_tokens_token_id ON {{schema}}.refresh_tokens (token_id);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_refresh_tokens_family_id ON {{schema}}.refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_refresh_tokens_profile_no ON {{schema}}.refresh_tokens (profile_no);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_{{schema}}_refresh_tokens_token_hash ON {{schema}}.refresh_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_refresh_tokens_expires_at ON {{schema}}.refresh_tokens (expires_at);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_refresh_tokens_revoked_at ON {{schema}}.refresh_tokens (revoked_at);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_refresh_tokens_created_at ON {{schema}}.refresh_tokens (created_at);
+
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_role_no' AND conrelid = '{{schema}}.users'::regclass) THEN
+		ALTER TABLE {{schema}}.users ADD CONSTRAINT fk_role_no FOREIGN KEY (role_no) REFERENCES {{schema}}.roles(role_no) ON UPDATE SET NULL;
+	END IF;
+
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_profile_no' AND conrelid = '{{schema}}.user_credentials'::regclass) THEN
+		ALTER TABLE {{schema}}.user_credentials ADD CONSTRAINT fk_profile_no FOREIGN KEY (profile_no) REFERENCES {{schema}}.users(profile_no) ON DELETE CASCADE;
+	END IF;
+
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_refresh_profile_no' AND conrelid = '{{schema}}.refresh_tokens'::regclass) THEN
+		ALTER TABLE {{schema}}.refresh_tokens ADD CONSTRAINT fk_refresh_profile_no FOREIGN KEY (profile_no) REFERENCES {{schema}}.users(profile_no) ON DELETE CASCADE;
+	END IF;
+END $$;
@@ -0,0 +1,4 @@
+-- Status a credential had before its user was soft-deleted, so restoring
+-- the user does not reactivate a credential that was inactive.
+
+ALTER TABLE {{schema}}.user_credentials ADD COLUMN IF NOT EXISTS restore_status status_enum DEFAULT NULL;
@@ -0,0 +1,13 @@
+-- Keyset pages of the user listing order a sort key with NULLs first and
+-- break ties on profile_no, see userRepo.GetPage; these indexes serve that
+-- order in both directions. role_name is sorted through the roles join and
+-- has no index here.
+
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_keyset_user_full_name ON {{schema}}.users (user_full_name NULLS FIRST, profile_no);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_keyset_email_id ON {{schema}}.users (email_id NULLS FIRST, profile_no);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_keyset_gender ON {{schema}}.users (gender NULLS FIRST, profile_no);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_keyset_dob ON {{schema}}.users (dob NULLS FIRST, profile_no);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_keyset_mobile_no ON {{schema}}.users (mobile_no NULLS FIRST, profile_no);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_keyset_status ON {{schema}}.users (status NULLS FIRST, profile_no);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_keyset_created_at ON {{schema}}.users (created_at NULLS FIRST, profile_no);
+CREATE INDEX IF NOT EXISTS idx_{{schema}}_users_keyset_updated_at ON {{schema}}.users (updated_at NULLS FIRST, profile_no);
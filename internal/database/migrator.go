@@ -0,0 +1,524 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql migrations/tenant/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey is the pg_advisory_lock key that serialises migration
+// runs across replicas started at the same time.
+const migrationLockKey int64 = 7_304_117_001
+
+const migrationsTable = "master.schema_migrations"
+
+// tenantMigrationsTable records the tenant migrations applied to the schema
+// it lives in.
+const tenantMigrationsTable = "tenant_migrations"
+
+// schemaPlaceholder is replaced with the tenant's schema name in tenant
+// migrations.
+const schemaPlaceholder = "{{schema}}"
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	// Modified is set when an applied migration's file no longer matches
+	// the checksum recorded when it ran.
+	Modified bool
+}
+
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// LoadMigrations returns the embedded migrations ordered by version.
+func LoadMigrations() ([]Migration, error) {
+	return loadMigrations(migrationFiles, "migrations")
+}
+
+// LoadTenantMigrations returns the embedded tenant migrations rendered for
+// schema. Checksums are taken before rendering, so they are the same in
+// every schema.
+func LoadTenantMigrations(schema string) ([]Migration, error) {
+
+	migrations, err := loadMigrations(migrationFiles, "migrations/tenant")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range migrations {
+		migrations[i].Up = strings.ReplaceAll(migrations[i].Up, schemaPlaceholder, schema)
+		migrations[i].Down = strings.ReplaceAll(migrations[i].Down, schemaPlaceholder, schema)
+	}
+
+	return migrations, nil
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// MigrateUp applies every pending migration in order, each in its own
+// transaction, then brings the default tenant and every registered tenant
+// schema up to date. It returns how many migrations were applied and
+// refuses to run when an already applied migration has been edited.
+func MigrateUp(ctx context.Context, db *gorm.DB) (int, error) {
+
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	err = withMigrationLock(ctx, db, func(conn *gorm.DB) error {
+
+		applied, err := applyMigrations(conn, migrations, migrationsTable)
+		count += applied
+		if err != nil {
+			return err
+		}
+
+		schemas, err := tenantSchemas(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, schema := range schemas {
+			applied, err := migrateSchema(conn, schema)
+			count += applied
+			if err != nil {
+				return fmt.Errorf("schema %s: %w", schema, err)
+			}
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// ProvisionTenant creates schema if needed and applies the pending tenant
+// migrations to it.
+func ProvisionTenant(ctx context.Context, db *gorm.DB, schema string) (int, error) {
+
+	count := 0
+
+	err := withMigrationLock(ctx, db, func(conn *gorm.DB) error {
+		var err error
+		count, err = migrateSchema(conn, schema)
+		return err
+	})
+
+	return count, err
+}
+
+// applyMigrations runs the migrations not yet recorded in table.
+func applyMigrations(conn *gorm.DB, migrations []Migration, table string) (int, error) {
+
+	applied, err := appliedMigrations(conn, table)
+	if err != nil {
+		return 0, err
+	}
+
+	pending, err := pendingMigrations(migrations, applied)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	for _, migration := range pending {
+
+		err := conn.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			return tx.Exec(
+				fmt.Sprintf(`INSERT INTO %s (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)`, table),
+				migration.Version, migration.Name, migration.Checksum, time.Now().UTC(),
+			).Error
+		})
+		if err != nil {
+			return count, fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+
+		slog.Info("migration applied", slog.String("table", table),
+			slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+		count++
+	}
+
+	return count, nil
+}
+
+// pendingMigrations returns the migrations missing from applied, in order.
+// Nothing is pending when an applied migration has been edited since it ran.
+func pendingMigrations(migrations []Migration, applied map[int64]appliedMigration) ([]Migration, error) {
+
+	var pending []Migration
+
+	for _, migration := range migrations {
+		record, ok := applied[migration.Version]
+		if !ok {
+			pending = append(pending, migration)
+			continue
+		}
+		if record.Checksum != migration.Checksum {
+			return nil, fmt.Errorf("migration %d_%s was modified after it was applied", migration.Version, migration.Name)
+		}
+	}
+
+	return pending, nil
+}
+
+func migrateSchema(conn *gorm.DB, schema string) (int, error) {
+
+	if !ValidSchemaName(schema) {
+		return 0, fmt.Errorf("invalid schema name %q", schema)
+	}
+
+	migrations, err := LoadTenantMigrations(schema)
+	if err != nil {
+		return 0, err
+	}
+
+	table := schema + "." + tenantMigrationsTable
+
+	if err := ensureMigrationsTable(conn, table); err != nil {
+		return 0, err
+	}
+
+	return applyMigrations(conn, migrations, table)
+}
+
+// tenantSchemas lists the default schema followed by the schema of every
+// tenant that is not deleted. Before the registry exists only the default
+// schema is returned.
+func tenantSchemas(conn *gorm.DB) ([]string, error) {
+
+	schemas := []string{DefaultSchema}
+
+	var registry *string
+	if err := conn.Raw(`SELECT to_regclass(?)::text`, models.Tenant{}.TableName()).Scan(&registry).Error; err != nil {
+		return nil, err
+	}
+	if registry == nil {
+		return schemas, nil
+	}
+
+	var names []string
+
+	query := fmt.Sprintf(`SELECT schema_name FROM %s WHERE status <> ? ORDER BY tenant_no`, models.Tenant{}.TableName())
+	if err := conn.Raw(query, models.Deleted).Scan(&names).Error; err != nil {
+		return nil, err
+	}
+
+	return append(schemas, names...), nil
+}
+
+// MigrateDown rolls back the latest steps applied migrations. Tenant
+// migrations are never rolled back; a tenant is removed with its schema.
+func MigrateDown(ctx context.Context, db *gorm.DB, steps int) (int, error) {
+
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	err = withMigrationLock(ctx, db, func(conn *gorm.DB) error {
+
+		applied, err := appliedMigrations(conn, migrationsTable)
+		if err != nil {
+			return err
+		}
+
+		rollback, err := rollbackMigrations(migrations, applied, steps)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range rollback {
+
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+				return tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE version = ?`, migrationsTable), migration.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("rollback of %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+
+			slog.Info("migration rolled back", slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// rollbackMigrations returns the latest steps applied migrations, newest
+// first. Nothing is rolled back when one of them has no down file.
+func rollbackMigrations(migrations []Migration, applied map[int64]appliedMigration, steps int) ([]Migration, error) {
+
+	byVersion := make(map[int64]Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+	rollback := make([]Migration, 0, min(steps, len(versions)))
+
+	for _, version := range versions[:min(steps, len(versions))] {
+		migration, ok := byVersion[version]
+		if !ok || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s has no down file", version, applied[version].Name)
+		}
+		rollback = append(rollback, migration)
+	}
+
+	return rollback, nil
+}
+
+// GetMigrationStatus reports every known migration and whether it is
+// applied, including applied versions whose files no longer exist.
+func GetMigrationStatus(ctx context.Context, db *gorm.DB) ([]MigrationStatus, error) {
+
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	return migrationStatus(db.WithContext(ctx), migrations, migrationsTable)
+}
+
+// GetTenantMigrationStatus reports the tenant migrations of schema.
+func GetTenantMigrationStatus(ctx context.Context, db *gorm.DB, schema string) ([]MigrationStatus, error) {
+
+	if !ValidSchemaName(schema) {
+		return nil, fmt.Errorf("invalid schema name %q", schema)
+	}
+
+	migrations, err := LoadTenantMigrations(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return migrationStatus(db.WithContext(ctx), migrations, schema+"."+tenantMigrationsTable)
+}
+
+// migrationStatus only reads: when table does not exist yet, every
+// migration is reported as pending.
+func migrationStatus(conn *gorm.DB, migrations []Migration, table string) ([]MigrationStatus, error) {
+
+	var bookkeeping *string
+	if err := conn.Raw(`SELECT to_regclass(?)::text`, table).Scan(&bookkeeping).Error; err != nil {
+		return nil, err
+	}
+
+	applied := map[int64]appliedMigration{}
+	if bookkeeping != nil {
+		var err error
+		if applied, err = appliedMigrations(conn, table); err != nil {
+			return nil, err
+		}
+	}
+
+	return statusReport(migrations, applied), nil
+}
+
+// statusReport lists migrations alongside the applied versions that no
+// longer have a file, ordered by version.
+func statusReport(migrations []Migration, applied map[int64]appliedMigration) []MigrationStatus {
+
+	var report []MigrationStatus
+
+	known := make(map[int64]bool, len(migrations))
+
+	for _, migration := range migrations {
+		known[migration.Version] = true
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Modified = record.Checksum != migration.Checksum
+		}
+		report = append(report, status)
+	}
+
+	for _, record := range applied {
+		if known[record.Version] {
+			continue
+		}
+		appliedAt := record.AppliedAt
+		report = append(report, MigrationStatus{
+			Version:   record.Version,
+			Name:      record.Name,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+			Modified:  true,
+		})
+	}
+
+	sort.Slice(report, func(i, j int) bool { return report[i].Version < report[j].Version })
+
+	return report
+}
+
+// withMigrationLock pins one connection, takes the advisory lock on it and
+// makes sure the bookkeeping table exists before calling fn.
+func withMigrationLock(ctx context.Context, db *gorm.DB, fn func(conn *gorm.DB) error) error {
+
+	return db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+
+		defer func() {
+			// The session context may already be cancelled; always unlock.
+			if err := conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(?)", migrationLockKey).Error; err != nil {
+				slog.Error("failed to release migration lock", slog.String("error", err.Error()))
+			}
+		}()
+
+		if err := ensureMigrationsTable(conn, migrationsTable); err != nil {
+			return err
+		}
+
+		return fn(conn)
+	})
+}
+
+// ensureMigrationsTable creates table, a schema-qualified name, and its
+// schema.
+func ensureMigrationsTable(conn *gorm.DB, table string) error {
+
+	schema, _, _ := strings.Cut(table, ".")
+
+	query := fmt.Sprintf(`
+		CREATE SCHEMA IF NOT EXISTS %s;
+		CREATE TABLE IF NOT EXISTS %s (
+			version    bigint PRIMARY KEY,
+			name       varchar(255) NOT NULL,
+			checksum   varchar(64) NOT NULL,
+			applied_at timestamptz NOT NULL DEFAULT now()
+		);`, schema, table)
+
+	if err := conn.Exec(query).Error; err != nil {
+		return fmt.Errorf("failed to create %s: %w", table, err)
+	}
+
+	return nil
+}
+
+func appliedMigrations(conn *gorm.DB, table string) (map[int64]appliedMigration, error) {
+
+	var rows []appliedMigration
+
+	query := fmt.Sprintf(`SELECT version, name, checksum, applied_at FROM %s ORDER BY version`, table)
+	if err := conn.Raw(query).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]appliedMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+
+	return applied, nil
+}
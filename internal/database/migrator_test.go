@@ -0,0 +1,237 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestLoadMigrations(t *testing.T) {
+
+	file := func(content string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(content)} }
+
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+		want  []Migration
+		err   string
+	}{
+		{
+			name: "ordered by version",
+			files: fstest.MapFS{
+				"m/0010_later.up.sql":    file("CREATE TABLE later ();"),
+				"m/0002_first.up.sql":    file("CREATE TABLE first ();"),
+				"m/0002_first.down.sql":  file("DROP TABLE first;"),
+				"m/0010_later.down.sql":  file("DROP TABLE later;"),
+				"m/0003_no_down.up.sql":  file("SELECT 1;"),
+				"m/nested/0001_x.up.sql": file("ignored"),
+			},
+			want: []Migration{
+				{Version: 2, Name: "first", Up: "CREATE TABLE first ();", Down: "DROP TABLE first;", Checksum: checksum("CREATE TABLE first ();")},
+				{Version: 3, Name: "no_down", Up: "SELECT 1;", Checksum: checksum("SELECT 1;")},
+				{Version: 10, Name: "later", Up: "CREATE TABLE later ();", Down: "DROP TABLE later;", Checksum: checksum("CREATE TABLE later ();")},
+			},
+		},
+		{
+			name:  "invalid file name",
+			files: fstest.MapFS{"m/0001_Initial.up.sql": file("SELECT 1;")},
+			err:   `invalid migration file name "0001_Initial.up.sql"`,
+		},
+		{
+			name:  "down without up",
+			files: fstest.MapFS{"m/0001_initial.down.sql": file("SELECT 1;")},
+			err:   "migration 1_initial has no up file",
+		},
+		{
+			name: "conflicting names",
+			files: fstest.MapFS{
+				"m/0001_initial.up.sql": file("SELECT 1;"),
+				"m/0001_other.down.sql": file("SELECT 1;"),
+			},
+			err: "conflicting names",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			got, err := loadMigrations(tt.files, "m")
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("loadMigrations() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadMigrations() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadMigrations() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Every embedded file must load, or the binary refuses to migrate.
+func TestEmbeddedMigrations(t *testing.T) {
+
+	if _, err := LoadMigrations(); err != nil {
+		t.Errorf("LoadMigrations() error = %v", err)
+	}
+
+	master, err := LoadTenantMigrations(DefaultSchema)
+	if err != nil {
+		t.Fatalf("LoadTenantMigrations() error = %v", err)
+	}
+	acme, err := LoadTenantMigrations("tenant_acme")
+	if err != nil {
+		t.Fatalf("LoadTenantMigrations() error = %v", err)
+	}
+
+	for i, migration := range acme {
+		if strings.Contains(migration.Up+migration.Down, schemaPlaceholder) {
+			t.Errorf("migration %d_%s still contains %s", migration.Version, migration.Name, schemaPlaceholder)
+		}
+		if !strings.Contains(migration.Up, "tenant_acme.") {
+			t.Errorf("migration %d_%s does not name the tenant schema", migration.Version, migration.Name)
+		}
+		if migration.Checksum != master[i].Checksum {
+			t.Errorf("migration %d_%s checksum differs between schemas", migration.Version, migration.Name)
+		}
+	}
+}
+
+func TestPendingMigrations(t *testing.T) {
+
+	migrations := []Migration{
+		{Version: 1, Name: "initial", Checksum: "a"},
+		{Version: 2, Name: "indexes", Checksum: "b"},
+		{Version: 3, Name: "tenants", Checksum: "c"},
+	}
+
+	tests := []struct {
+		name    string
+		applied map[int64]appliedMigration
+		want    []int64
+		err     string
+	}{
+		{"fresh database", nil, []int64{1, 2, 3}, ""},
+		{"partly applied", map[int64]appliedMigration{1: {Checksum: "a"}}, []int64{2, 3}, ""},
+		{"gap is filled", map[int64]appliedMigration{1: {Checksum: "a"}, 3: {Checksum: "c"}}, []int64{2}, ""},
+		{"up to date", map[int64]appliedMigration{1: {Checksum: "a"}, 2: {Checksum: "b"}, 3: {Checksum: "c"}}, nil, ""},
+		{"edited after it ran", map[int64]appliedMigration{1: {Checksum: "a"}, 2: {Checksum: "x"}}, nil, "migration 2_indexes was modified after it was applied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			pending, err := pendingMigrations(migrations, tt.applied)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("pendingMigrations() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("pendingMigrations() error = %v", err)
+			}
+			if got := versions(pending); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pending = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRollbackMigrations(t *testing.T) {
+
+	migrations := []Migration{
+		{Version: 1, Name: "initial", Down: "DROP 1"},
+		{Version: 2, Name: "indexes"},
+		{Version: 3, Name: "tenants", Down: "DROP 3"},
+		{Version: 4, Name: "pending", Down: "DROP 4"},
+	}
+	applied := map[int64]appliedMigration{1: {Name: "initial"}, 2: {Name: "indexes"}, 3: {Name: "tenants"}}
+
+	tests := []struct {
+		name    string
+		applied map[int64]appliedMigration
+		steps   int
+		want    []int64
+		err     string
+	}{
+		{"latest first", applied, 1, []int64{3}, ""},
+		{"steps past a missing down file", applied, 2, nil, "migration 2_indexes has no down file"},
+		{"file removed since it ran", map[int64]appliedMigration{5: {Name: "gone"}}, 1, nil, "migration 5_gone has no down file"},
+		{"more steps than applied", map[int64]appliedMigration{1: {Name: "initial"}, 3: {Name: "tenants"}}, 5, []int64{3, 1}, ""},
+		{"nothing applied", nil, 1, []int64{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			rollback, err := rollbackMigrations(migrations, tt.applied, tt.steps)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("rollbackMigrations() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("rollbackMigrations() error = %v", err)
+			}
+			if got := versions(rollback); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rollback = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusReport(t *testing.T) {
+
+	appliedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	migrations := []Migration{
+		{Version: 1, Name: "initial", Checksum: "a"},
+		{Version: 2, Name: "indexes", Checksum: "b"},
+		{Version: 4, Name: "pending", Checksum: "d"},
+	}
+	applied := map[int64]appliedMigration{
+		1: {Version: 1, Name: "initial", Checksum: "a", AppliedAt: appliedAt},
+		2: {Version: 2, Name: "indexes", Checksum: "x", AppliedAt: appliedAt},
+		3: {Version: 3, Name: "removed", Checksum: "c", AppliedAt: appliedAt},
+	}
+
+	want := []MigrationStatus{
+		{Version: 1, Name: "initial", Applied: true, AppliedAt: &appliedAt},
+		{Version: 2, Name: "indexes", Applied: true, AppliedAt: &appliedAt, Modified: true},
+		{Version: 3, Name: "removed", Applied: true, AppliedAt: &appliedAt, Modified: true},
+		{Version: 4, Name: "pending"},
+	}
+
+	if got := statusReport(migrations, applied); !reflect.DeepEqual(got, want) {
+		t.Errorf("statusReport() = %+v, want %+v", got, want)
+	}
+	if len(applied) != 3 {
+		t.Errorf("statusReport() changed the applied migrations")
+	}
+}
+
+func versions(migrations []Migration) []int64 {
+	if migrations == nil {
+		return nil
+	}
+	got := make([]int64, 0, len(migrations))
+	for _, migration := range migrations {
+		got = append(got, migration.Version)
+	}
+	return got
+}
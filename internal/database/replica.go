@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type replica struct {
+	name    string
+	db      *gorm.DB
+	healthy atomic.Bool
+}
+
+// ReplicaStatus is the last health check result of one replica.
+type ReplicaStatus struct {
+	Name    string
+	Healthy bool
+}
+
+var (
+	replicas    []*replica
+	nextReplica atomic.Uint64
+)
+
+type pinKey struct{}
+
+// initReplicas opens every configured replica, checks it once and keeps
+// checking in the background. A replica that is down at startup is not an
+// error; it starts taking reads once it answers.
+func initReplicas(cfg config.Database) error {
+
+	opened := make([]*replica, 0, len(cfg.Replicas))
+
+	for _, endpoint := range cfg.Replicas {
+		replicaCfg := cfg.ForReplica(endpoint)
+
+		conn, err := gorm.Open(postgres.Open(replicaCfg.DSN()), &gorm.Config{DisableAutomaticPing: true})
+		if err != nil {
+			return fmt.Errorf("replica %s: %w", endpoint.Host, err)
+		}
+
+		if err := configurePool(conn, replicaCfg); err != nil {
+			return fmt.Errorf("replica %s: %w", endpoint.Host, err)
+		}
+		if err := RegisterErrorCallbacks(conn); err != nil {
+			return fmt.Errorf("replica %s: %w", endpoint.Host, err)
+		}
+
+		opened = append(opened, &replica{name: fmt.Sprintf("%s:%d", replicaCfg.Host, replicaCfg.Port), db: conn})
+	}
+
+	replicas = opened
+
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	checkReplicas()
+
+	go func() {
+		ticker := time.NewTicker(cfg.ReplicaHealthInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			checkReplicas()
+		}
+	}()
+
+	return nil
+}
+
+func checkReplicas() {
+
+	for _, r := range replicas {
+		healthy := pingReplica(r) == nil
+		if r.healthy.Swap(healthy) != healthy {
+			if healthy {
+				slog.Info("read replica is healthy", slog.String("replica", r.name))
+			} else {
+				slog.Warn("read replica is unhealthy, reads fall back to the primary", slog.String("replica", r.name))
+			}
+		}
+	}
+}
+
+func pingReplica(r *replica) error {
+
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	return sqlDB.PingContext(ctx)
+}
+
+// PinPrimary marks ctx so Reader sends its queries to the primary. It is
+// set for requests that write, so they read their own writes.
+func PinPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pinKey{}, true)
+}
+
+// Reader returns a handle for read-only queries: a healthy replica, picked
+// round-robin, unless ctx carries a transaction or is pinned to the primary,
+// or no replica is healthy.
+func Reader(ctx context.Context, db *gorm.DB) *gorm.DB {
+
+	if _, inTx := ctx.Value(txKey{}).(*gorm.DB); inTx {
+		return Conn(ctx, db)
+	}
+	if pinned, _ := ctx.Value(pinKey{}).(bool); pinned || len(replicas) == 0 {
+		return db.WithContext(ctx)
+	}
+
+	start := nextReplica.Add(1)
+	for i := range replicas {
+		r := replicas[(start+uint64(i))%uint64(len(replicas))]
+		if r.healthy.Load() {
+			return r.db.WithContext(ctx)
+		}
+	}
+
+	return db.WithContext(ctx)
+}
+
+// ReplicaStatuses reports the configured replicas in config order.
+func ReplicaStatuses() []ReplicaStatus {
+
+	statuses := make([]ReplicaStatus, 0, len(replicas))
+	for _, r := range replicas {
+		statuses = append(statuses, ReplicaStatus{Name: r.name, Healthy: r.healthy.Load()})
+	}
+
+	return statuses
+}
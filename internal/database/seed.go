@@ -0,0 +1,297 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	utils "github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+//go:embed seeds/*.yaml
+var seedFiles embed.FS
+
+// seedExtensions are tried in order for <env>.<ext>. JSON is read with the
+// YAML decoder, which accepts it as a subset.
+var seedExtensions = []string{"yaml", "yml", "json"}
+
+type SeedData struct {
+	Sections []SeedSection `yaml:"sections" validate:"dive"`
+	Roles    []SeedRole    `yaml:"roles" validate:"dive"`
+	Users    []SeedUser    `yaml:"users" validate:"dive"`
+}
+
+type SeedSection struct {
+	Name  string     `yaml:"name" validate:"required"`
+	Path  string     `yaml:"path" validate:"required"`
+	Icon  string     `yaml:"icon"`
+	Order uint8      `yaml:"order"`
+	Pages []SeedPage `yaml:"pages" validate:"dive"`
+}
+
+type SeedPage struct {
+	Name  string `yaml:"name" validate:"required"`
+	Path  string `yaml:"path" validate:"required"`
+	Order uint8  `yaml:"order"`
+}
+
+type SeedRole struct {
+	Name        string            `yaml:"name" validate:"required"`
+	Permissions dto.PermissionSet `yaml:"permissions" validate:"dive"`
+}
+
+// SeedUser is upserted by Username. The password is only set when the
+// account is created: from the environment variable named by PasswordEnv,
+// or generated and printed once.
+type SeedUser struct {
+	Username    string `yaml:"username" validate:"required"`
+	FullName    string `yaml:"full_name" validate:"required"`
+	Email       string `yaml:"email" validate:"required,email"`
+	Mobile      string `yaml:"mobile"`
+	Role        string `yaml:"role" validate:"required"`
+	PasswordEnv string `yaml:"password_env"`
+}
+
+type generatedPassword struct {
+	username string
+	password string
+}
+
+// LoadSeedData reads the seed definitions for env from dir, or from the
+// definitions embedded in the binary when dir is empty.
+func LoadSeedData(env, dir string) (*SeedData, error) {
+
+	var fsys fs.FS = os.DirFS(dir)
+	if dir == "" {
+		sub, err := fs.Sub(seedFiles, "seeds")
+		if err != nil {
+			return nil, err
+		}
+		fsys = sub
+	}
+
+	for _, ext := range seedExtensions {
+		name := env + "." + ext
+
+		content, err := fs.ReadFile(fsys, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var data SeedData
+		if err := yaml.Unmarshal(content, &data); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+
+		if err := validator.New().Struct(data); err != nil {
+			return nil, fmt.Errorf("invalid seed file %s: %w", name, err)
+		}
+
+		return &data, nil
+	}
+
+	return nil, fmt.Errorf("no seed definitions for environment %q", env)
+}
+
+// SeedDatabase upserts the seed definitions for env in one transaction.
+// Sections and pages are shared; roles and users go to the schema of the
+// tenant ctx is scoped to. Running it again updates the seeded records in
+// place and never resets an existing password.
+func SeedDatabase(ctx context.Context, db *gorm.DB, env, dir string) error {
+
+	data, err := LoadSeedData(env, dir)
+	if err != nil {
+		return err
+	}
+
+	var generated []generatedPassword
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+
+		now := time.Now().UTC()
+
+		for _, section := range data.Sections {
+			sectionNo, err := upsertSection(tx, section, now)
+			if err != nil {
+				return fmt.Errorf("failed to seed section %q: %w", section.Path, err)
+			}
+
+			for _, page := range section.Pages {
+				if err := upsertPage(tx, sectionNo, page, now); err != nil {
+					return fmt.Errorf("failed to seed page %q: %w", page.Path, err)
+				}
+			}
+		}
+
+		roleNos := make(map[string]uint8, len(data.Roles))
+
+		for _, role := range data.Roles {
+			roleNo, err := upsertRole(ctx, tx, role, now)
+			if err != nil {
+				return fmt.Errorf("failed to seed role %q: %w", role.Name, err)
+			}
+			roleNos[role.Name] = roleNo
+		}
+
+		for _, user := range data.Users {
+			roleNo, ok := roleNos[user.Role]
+			if !ok {
+				return fmt.Errorf("user %q references unknown role %q", user.Username, user.Role)
+			}
+
+			password, err := upsertUser(ctx, tx, user, roleNo, now)
+			if err != nil {
+				return fmt.Errorf("failed to seed user %q: %w", user.Username, err)
+			}
+			if password != "" {
+				generated = append(generated, generatedPassword{username: user.Username, password: password})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Only printed after commit, so a failed run never shows a password
+	// that was not stored.
+	for _, entry := range generated {
+		fmt.Fprintf(os.Stdout, "Generated password for %q: %s\n", entry.username, entry.password)
+	}
+
+	slog.Info("seed data applied", slog.String("env", env), slog.String("schema", TenantSchema(ctx)),
+		slog.Int("sections", len(data.Sections)), slog.Int("roles", len(data.Roles)), slog.Int("users", len(data.Users)))
+
+	return nil
+}
+
+func upsertSection(tx *gorm.DB, section SeedSection, now time.Time) (uint8, error) {
+
+	var sectionNo uint8
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (section_id, section_name, section_path, section_icon, section_order, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (section_path) DO UPDATE SET
+			section_name = EXCLUDED.section_name,
+			section_icon = EXCLUDED.section_icon,
+			section_order = EXCLUDED.section_order,
+			updated_at = EXCLUDED.updated_at
+		RETURNING section_no`, models.Section{}.TableName())
+
+	err := tx.Raw(query, uuid.New(), section.Name, section.Path, section.Icon, section.Order, models.Active, now, now).
+		Scan(&sectionNo).Error
+
+	return sectionNo, err
+}
+
+func upsertPage(tx *gorm.DB, sectionNo uint8, page SeedPage, now time.Time) error {
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (page_id, section_no, page_name, page_path, page_order, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (page_path) DO UPDATE SET
+			section_no = EXCLUDED.section_no,
+			page_name = EXCLUDED.page_name,
+			page_order = EXCLUDED.page_order,
+			updated_at = EXCLUDED.updated_at`, models.Page{}.TableName())
+
+	return tx.Exec(query, uuid.New(), sectionNo, page.Name, page.Path, page.Order, models.Active, now, now).Error
+}
+
+func upsertRole(ctx context.Context, tx *gorm.DB, role SeedRole, now time.Time) (uint8, error) {
+
+	var roleNo uint8
+
+	permissions := role.Permissions
+	if permissions == nil {
+		permissions = dto.PermissionSet{}
+	}
+
+	// The conflict target must repeat the partial index predicate from
+	// migration 0002.
+	query := fmt.Sprintf(`
+		INSERT INTO %s (role_id, role_name, role_details, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (role_name) WHERE status <> 'D' DO UPDATE SET
+			role_details = EXCLUDED.role_details,
+			updated_at = EXCLUDED.updated_at
+		RETURNING role_no`, Table(ctx, "roles"))
+
+	err := tx.Raw(query, uuid.New(), role.Name, permissions, models.Active, now, now).Scan(&roleNo).Error
+
+	return roleNo, err
+}
+
+// upsertUser returns the generated password when it had to create the
+// account without one from the environment.
+func upsertUser(ctx context.Context, tx *gorm.DB, user SeedUser, roleNo uint8, now time.Time) (string, error) {
+
+	var profileNos []uint32
+
+	lookup := fmt.Sprintf(`SELECT profile_no FROM %s WHERE username = ?`, Table(ctx, "user_credentials"))
+	if err := tx.Raw(lookup, user.Username).Scan(&profileNos).Error; err != nil {
+		return "", err
+	}
+
+	if len(profileNos) > 0 {
+		update := fmt.Sprintf(`
+			UPDATE %s SET user_full_name = ?, email_id = ?, mobile_no = ?, role_no = ?, updated_at = ?
+			WHERE profile_no = ?`, Table(ctx, "users"))
+
+		return "", tx.Exec(update, user.FullName, user.Email, user.Mobile, roleNo, now, profileNos[0]).Error
+	}
+
+	password, generated := "", ""
+	if user.PasswordEnv != "" {
+		password = os.Getenv(user.PasswordEnv)
+	}
+	if password == "" {
+		var err error
+		if password, err = utils.GeneratePassword(); err != nil {
+			return "", err
+		}
+		generated = password
+	}
+
+	hashed, err := utils.HashPassword(password)
+	if err != nil {
+		return "", err
+	}
+
+	var profileNo uint32
+
+	insertUser := fmt.Sprintf(`
+		INSERT INTO %s (profile_id, role_no, user_full_name, email_id, mobile_no, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING profile_no`, Table(ctx, "users"))
+
+	err = tx.Raw(insertUser, uuid.New(), roleNo, user.FullName, user.Email, user.Mobile, models.Active, now, now).
+		Scan(&profileNo).Error
+	if err != nil {
+		return "", err
+	}
+
+	insertCredential := fmt.Sprintf(`
+		INSERT INTO %s (credential_id, profile_no, username, password, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`, Table(ctx, "user_credentials"))
+
+	err = tx.Exec(insertCredential, uuid.New(), profileNo, user.Username, hashed, models.Active, now, now).Error
+
+	return generated, err
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"gorm.io/gorm"
+)
+
+// DefaultSchema holds the shared tables and the tables of the default
+// tenant, used when a request names no tenant.
+const DefaultSchema = "master"
+
+var schemaNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// ValidSchemaName reports whether name can be interpolated into SQL as an
+// unquoted identifier.
+func ValidSchemaName(name string) bool {
+	return schemaNamePattern.MatchString(name)
+}
+
+// TenantSchema returns the schema of the tenant ctx is scoped to.
+func TenantSchema(ctx context.Context) string {
+	if tenant, ok := utils.TenantFromContext(ctx); ok && tenant.SchemaName != "" {
+		return tenant.SchemaName
+	}
+	return DefaultSchema
+}
+
+// Table qualifies a tenant table name with the schema of the tenant ctx is
+// scoped to.
+func Table(ctx context.Context, name string) string {
+	return TenantSchema(ctx) + "." + name
+}
+
+type tenantProvisioner struct {
+	db *gorm.DB
+}
+
+func NewTenantProvisioner(db *gorm.DB) interfaces.TenantProvisioner {
+	return &tenantProvisioner{db: db}
+}
+
+func (p *tenantProvisioner) Provision(ctx context.Context, schema string) error {
+	_, err := ProvisionTenant(ctx, p.db, schema)
+	return err
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+)
+
+func TestValidSchemaName(t *testing.T) {
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"master", true},
+		{"tenant_big_co", true},
+		{"t" + strings.Repeat("a", 62), true},
+		{"t" + strings.Repeat("a", 63), false},
+		{"", false},
+		{"1tenant", false},
+		{"Tenant", false},
+		{"tenant-acme", false},
+		{"tenant_acme; DROP SCHEMA master", false},
+		{`"tenant"`, false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidSchemaName(tt.name); got != tt.want {
+			t.Errorf("ValidSchemaName(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTable(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		tenant *dto.TenantDTO
+		want   string
+	}{
+		{"no tenant", nil, "master.roles"},
+		{"tenant without a schema", &dto.TenantDTO{Slug: "acme"}, "master.roles"},
+		{"tenant", &dto.TenantDTO{Slug: "acme", SchemaName: "tenant_acme"}, "tenant_acme.roles"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			ctx := context.Background()
+			if tt.tenant != nil {
+				ctx = utils.WithTenant(ctx, tt.tenant)
+			}
+
+			if got := Table(ctx, "roles"); got != tt.want {
+				t.Errorf("Table() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
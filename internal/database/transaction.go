@@ -0,0 +1,40 @@
+package db
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type txManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) interfaces.Transactor {
+	return &txManager{db: db}
+}
+
+// Do commits when fn returns nil and rolls back when it returns an error or
+// panics; the panic is re-raised after the rollback.
+func (m *txManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+
+	// GORM turns a Transaction call on an open transaction into a savepoint.
+	return Conn(ctx, m.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// Conn returns the transaction carried by ctx, or db when there is none,
+// bound to ctx. Repositories use it for every query so they take part in a
+// transaction opened by a service without knowing about it.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+)
+
+type LoginRequestDTO struct {
+	Username  string `json:"username" validate:"required"`
+	Password  string `json:"password" validate:"required"`
+	UserAgent string `json:"-"`
+	IpAddress string `json:"-"`
+}
+
+type RefreshRequestDTO struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+	UserAgent    string `json:"-"`
+	IpAddress    string `json:"-"`
+}
+
+type LoginResponseDTO struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// CredentialDTO is the login view of a user: the credential row joined with
+// the profile and role it belongs to.
+type CredentialDTO struct {
+	CredentialNo uint32
+	ProfileNo    uint32
+	ProfileId    uuid.UUID
+	Username     string
+	Password     string
+	Status       models.StatusEnum
+	RoleId       uuid.UUID
+	RoleName     string
+}
+
+// Principal is the authenticated caller resolved from a verified access token.
+type Principal struct {
+	ProfileNo uint32    `json:"profile_no"`
+	ProfileId uuid.UUID `json:"profile_id"`
+	RoleId    uuid.UUID `json:"role_id"`
+	Role      string    `json:"role"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/google/uuid"
+
+type MenuSectionDTO struct {
+	SectionId    uuid.UUID     `json:"section_id"`
+	SectionName  string        `json:"section_name"`
+	SectionPath  string        `json:"section_path"`
+	SectionIcon  string        `json:"section_icon"`
+	SectionOrder uint8         `json:"section_order"`
+	Pages        []MenuPageDTO `json:"pages"`
+}
+
+type MenuPageDTO struct {
+	PageId    uuid.UUID `json:"page_id"`
+	PageName  string    `json:"page_name"`
+	PagePath  string    `json:"page_path"`
+	PageOrder uint8     `json:"page_order"`
+	Path      string    `json:"path"`
+}
+
+// MenuRowDTO is one active page joined with its active section.
+type MenuRowDTO struct {
+	SectionId    uuid.UUID
+	SectionName  string
+	SectionPath  string
+	SectionIcon  string
+	SectionOrder uint8
+	PageId       uuid.UUID
+	PageName     string
+	PagePath     string
+	PageOrder    uint8
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+)
+
+type PageRequestDTO struct {
+	PageName  string            `json:"page_name" validate:"required,max=65" update:"omitempty,max=65"`
+	PagePath  string            `json:"page_path" validate:"required,max=255" update:"omitempty,max=255"`
+	PageOrder uint8             `json:"page_order"`
+	Status    models.StatusEnum `json:"status" validate:"omitempty,oneof=A I"`
+}
+
+type PageMoveDTO struct {
+	SectionId uuid.UUID `json:"section_id" validate:"required"`
+	PageOrder uint8     `json:"page_order"`
+}
+
+type PageResponseDTO struct {
+	PageId    uuid.UUID         `json:"page_id"`
+	SectionId uuid.UUID         `json:"section_id"`
+	PageName  string            `json:"page_name"`
+	PagePath  string            `json:"page_path"`
+	PageOrder uint8             `json:"page_order"`
+	Status    models.StatusEnum `json:"status"`
+	CreatedAt time.Time         `json:"created_at"`
+	CreatedBy uint32            `json:"created_by"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	UpdatedBy uint32            `json:"updated_by"`
+}
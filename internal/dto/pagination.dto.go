@@ -1,5 +1,46 @@
 package dto
 
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Count modes of listings that support skipping or estimating the total.
+const (
+	CountExact    = "exact"
+	CountEstimate = "estimate"
+	CountNone     = "none"
+)
+
+// Cursor marks a row of a keyset-paginated listing: the values of its sort
+// keys and its primary key. Sort is the sort the cursor was issued for, as
+// given in the sort parameter.
+type Cursor struct {
+	Sort   string          `json:"s"`
+	Values json.RawMessage `json:"v"`
+	Id     int64           `json:"id"`
+	// Before asks for the rows preceding this one instead of following it.
+	Before bool `json:"b,omitempty"`
+}
+
+// CursorPage is the position and count information of one page of a
+// keyset-paginated listing.
+type CursorPage struct {
+	NextCursor     string `json:"next_cursor,omitempty"`
+	PrevCursor     string `json:"prev_cursor,omitempty"`
+	TotalRecords   *int64 `json:"total_records,omitempty"`
+	TotalEstimated bool   `json:"total_estimated,omitempty"`
+}
+
+// SortField is one whitelisted sort key of a listing, in priority order.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
 type PaginationParams struct {
 	Page   int
 	Size   int
@@ -7,4 +48,57 @@ type PaginationParams struct {
 	Status string
 	SortBy string
 	Order  string
+	// Sort is the multi-column sort of listings that support it; it holds
+	// field names already checked against the listing's whitelist.
+	Sort []SortField
+
+	// Keyset pagination. UseCursor selects it; Cursor is nil on the first
+	// page. Count is one of the Count* modes.
+	UseCursor bool
+	Cursor    *Cursor
+	Count     string
+
+	// Filters of the user listing. Date ranges are inclusive and compare
+	// whole days.
+	RoleId      *uuid.UUID
+	Gender      string
+	MobileNo    string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	DobFrom     *time.Time
+	DobTo       *time.Time
+}
+
+// SortSpec spells sort the way the sort query parameter does, e.g.
+// "role_name,-created_at". Cursors are checked against it.
+func SortSpec(sort []SortField) string {
+
+	fields := make([]string, 0, len(sort))
+	for _, field := range sort {
+		if field.Desc {
+			fields = append(fields, "-"+field.Field)
+		} else {
+			fields = append(fields, field.Field)
+		}
+	}
+
+	return strings.Join(fields, ",")
+}
+
+// PageResult is one page of an offset-paginated listing.
+type PageResult[T any] struct {
+	Items        []T   `json:"items"`
+	TotalRecords int64 `json:"total_records"`
+	TotalPages   int   `json:"total_pages"`
+}
+
+// NewPageResult derives TotalPages from total and the page size.
+func NewPageResult[T any](items []T, total int64, size int) PageResult[T] {
+
+	pages := 0
+	if size > 0 {
+		pages = int((total + int64(size) - 1) / int64(size))
+	}
+
+	return PageResult[T]{Items: items, TotalRecords: total, TotalPages: pages}
 }
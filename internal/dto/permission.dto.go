@@ -0,0 +1,95 @@
+package dto
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+)
+
+const (
+	ActionView   = "view"
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+	// ActionPurge permanently removes soft-deleted records.
+	ActionPurge = "purge"
+
+	// PermissionWildcard matches every page or every action.
+	PermissionWildcard = "*"
+)
+
+// PagePermission grants actions on one page, identified by its page_path.
+type PagePermission struct {
+	PagePath string   `json:"page_path" yaml:"page_path" validate:"required"`
+	Actions  []string `json:"actions" yaml:"actions" validate:"required,dive,oneof=view create update delete purge *"`
+}
+
+// PermissionSet is the structure stored in master.roles.role_details.
+type PermissionSet []PagePermission
+
+func ParsePermissionSet(raw string) (PermissionSet, error) {
+	permissions := PermissionSet{}
+	if strings.TrimSpace(raw) == "" {
+		return permissions, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &permissions); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (p PermissionSet) Allows(page, action string) bool {
+	for _, permission := range p {
+		if permission.PagePath != page && permission.PagePath != PermissionWildcard {
+			continue
+		}
+		for _, granted := range permission.Actions {
+			if granted == action || granted == PermissionWildcard {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Scan lets a jsonb role_details column be read straight into a PermissionSet.
+func (p *PermissionSet) Scan(value interface{}) error {
+	var raw string
+	switch v := value.(type) {
+	case nil:
+		*p = PermissionSet{}
+		return nil
+	case []byte:
+		raw = string(v)
+	case string:
+		raw = v
+	default:
+		return fmt.Errorf("cannot scan %T into PermissionSet", value)
+	}
+
+	permissions, err := ParsePermissionSet(raw)
+	if err != nil {
+		return err
+	}
+	*p = permissions
+	return nil
+}
+
+func (p PermissionSet) Value() (driver.Value, error) {
+	if p == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+type RolePermissionsDTO struct {
+	RoleDetails PermissionSet
+	Status      models.StatusEnum
+}
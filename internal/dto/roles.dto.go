@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+)
+
+// RoleRequestDTO is the body of role create and update requests. Status is
+// only accepted on create; updates change it through RoleStatusDTO.
+type RoleRequestDTO struct {
+	RoleName    string            `json:"role_name" validate:"required,max=65" update:"omitempty,max=65"`
+	RoleDetails PermissionSet     `json:"role_details" validate:"dive"`
+	Status      models.StatusEnum `json:"status" validate:"omitempty,oneof=A I"`
+}
+
+type RoleCloneDTO struct {
+	RoleName string `json:"role_name" validate:"required,max=65"`
+}
+
+type RoleStatusDTO struct {
+	Status models.StatusEnum `json:"status" validate:"required,oneof=A I D"`
+}
+
+type RoleResponseDTO struct {
+	RoleId      uuid.UUID         `json:"role_id"`
+	RoleName    string            `json:"role_name"`
+	RoleDetails PermissionSet     `json:"role_details"`
+	Status      models.StatusEnum `json:"status"`
+	ActiveUsers int64             `json:"active_users" gorm:"->"`
+	CreatedAt   time.Time         `json:"created_at"`
+	CreatedBy   uint32            `json:"created_by"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	UpdatedBy   uint32            `json:"updated_by"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+)
+
+type SectionRequestDTO struct {
+	SectionName  string            `json:"section_name" validate:"required,max=65" update:"omitempty,max=65"`
+	SectionPath  string            `json:"section_path" validate:"required,max=65" update:"omitempty,max=65"`
+	SectionIcon  string            `json:"section_icon" validate:"max=65"`
+	SectionOrder uint8             `json:"section_order"`
+	Status       models.StatusEnum `json:"status" validate:"omitempty,oneof=A I"`
+}
+
+type SectionResponseDTO struct {
+	SectionId    uuid.UUID         `json:"section_id"`
+	SectionName  string            `json:"section_name"`
+	SectionPath  string            `json:"section_path"`
+	SectionIcon  string            `json:"section_icon"`
+	SectionOrder uint8             `json:"section_order"`
+	Status       models.StatusEnum `json:"status"`
+	CreatedAt    time.Time         `json:"created_at"`
+	CreatedBy    uint32            `json:"created_by"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	UpdatedBy    uint32            `json:"updated_by"`
+	Pages        []PageResponseDTO `json:"pages,omitempty" gorm:"-"`
+}
+
+// ReorderDTO assigns new order values to a set of sections or pages.
+type ReorderDTO struct {
+	Items []ReorderItemDTO `json:"items" validate:"required,min=1,dive"`
+}
+
+type ReorderItemDTO struct {
+	Id    uuid.UUID `json:"id" validate:"required"`
+	Order uint8     `json:"order" validate:"required,min=1"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+// DatabaseStatsDTO reports reachability and connection pool usage of the
+// primary plus the health of each read replica. Wait and close counters are
+// cumulative since the pool was opened.
+type DatabaseStatsDTO struct {
+	Healthy            bool   `json:"healthy"`
+	Error              string `json:"error,omitempty"`
+	PingMs             int64  `json:"ping_ms"`
+	MaxOpenConnections int    `json:"max_open_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"wait_count"`
+	WaitDurationMs     int64  `json:"wait_duration_ms"`
+	MaxIdleClosed      int64  `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64  `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64  `json:"max_lifetime_closed"`
+
+	Replicas []ReplicaStatusDTO `json:"replicas"`
+}
+
+type ReplicaStatusDTO struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+)
+
+type TenantRequestDTO struct {
+	Slug       string `json:"slug" validate:"required"`
+	TenantName string `json:"tenant_name" validate:"required"`
+}
+
+type TenantDTO struct {
+	TenantId   uuid.UUID         `json:"tenant_id"`
+	Slug       string            `json:"slug"`
+	TenantName string            `json:"tenant_name"`
+	SchemaName string            `json:"schema_name"`
+	Status     models.StatusEnum `json:"status"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
@@ -7,24 +7,22 @@ import (
 	"github.com/google/uuid"
 )
 
+// RequestDTO is the body of user create and update requests. Updates are
+// partial: they check the update tags, where set, instead of validate.
 type RequestDTO struct {
 	ProfileId    uuid.UUID         `json:"profile_id"`
-	RoleId       uuid.UUID         `json:"role_id" validate:"required"`
-	UserFullName string            `json:"user_fullname" validate:"required"`
-	Username     string            `json:"username" validate:"required"`
-	Password     string            `json:"password" validate:"required"`
-	EmailId      string            `json:"email_id" validate:"required"`
-	Gender       string            `json:"gender"`
+	RoleId       uuid.UUID         `json:"role_id" validate:"required" update:"omitempty"`
+	UserFullName string            `json:"user_fullname" validate:"required,max=65" update:"omitempty,max=65"`
+	Username     string            `json:"username" validate:"required,max=65" update:"omitempty,max=65"`
+	Password     string            `json:"password" validate:"required,min=8,max=72" update:"omitempty,min=8,max=72"`
+	EmailId      string            `json:"email_id" validate:"required,email,max=65" update:"omitempty,email,max=65"`
+	Gender       string            `json:"gender" validate:"max=65"`
 	Dob          *time.Time        `json:"dob"`
-	MobileNo     string            `json:"mobile_no"`
+	MobileNo     string            `json:"mobile_no" validate:"omitempty,e164,max=15"`
 	Address      string            `json:"address"`
-	XApiKey      string            `json:"x_api_key"`
-	SecretKey    string            `json:"secret_key"`
-	Status       models.StatusEnum `json:"status"`
-	CreatedAt    time.Time         `json:"created_at"`
-	CreatedBy    uint32            `json:"created_by"`
-	UpdatedAt    time.Time         `json:"updated_at"`
-	UpdatedBy    uint32            `json:"updated_by"`
+	XApiKey      string            `json:"x_api_key" validate:"max=55"`
+	SecretKey    string            `json:"secret_key" validate:"max=55"`
+	Status       models.StatusEnum `json:"status" validate:"omitempty,oneof=A I"`
 }
 
 type ResponseDTO struct {
@@ -44,3 +42,17 @@ type ResponseDTO struct {
 	UpdatedAt    time.Time         `json:"updated_at"`
 	UpdatedBy    uint32            `json:"updated_by"`
 }
+
+// UserSortFields maps the sort fields accepted by the user listing to the
+// columns they order by.
+var UserSortFields = map[string]string{
+	"user_full_name": "profile.user_full_name",
+	"email_id":       "profile.email_id",
+	"gender":         "profile.gender",
+	"dob":            "profile.dob",
+	"mobile_no":      "profile.mobile_no",
+	"status":         "profile.status",
+	"created_at":     "profile.created_at",
+	"updated_at":     "profile.updated_at",
+	"role_name":      "role.role_name",
+}
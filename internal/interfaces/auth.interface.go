@@ -0,0 +1,40 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/apperrors"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+)
+
+var (
+	ErrInvalidCredentials  = apperrors.New(apperrors.Unauthorized, "invalid username or password")
+	ErrInactiveCredential  = apperrors.New(apperrors.Forbidden, "credential is not active")
+	ErrInvalidRefreshToken = apperrors.New(apperrors.Unauthorized, "refresh token is invalid or expired")
+	ErrRefreshTokenReused  = apperrors.New(apperrors.Unauthorized, "refresh token has already been used")
+)
+
+type AuthService interface {
+	Login(ctx context.Context, data dto.LoginRequestDTO) (*dto.LoginResponseDTO, error)
+	Refresh(ctx context.Context, data dto.RefreshRequestDTO) (*dto.LoginResponseDTO, error)
+	Logout(ctx context.Context, data dto.RefreshRequestDTO) error
+}
+
+type AuthRepository interface {
+	// FindCredentialByUsername returns nil without an error when no
+	// credential matches the username.
+	FindCredentialByUsername(ctx context.Context, username string) (*dto.CredentialDTO, error)
+	FindCredentialByProfileNo(ctx context.Context, profileNo uint32) (*dto.CredentialDTO, error)
+
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error
+
+	// RotateRefreshToken marks the token identified by hash as used and
+	// stores next in the same family. Presenting an already used token
+	// revokes the whole family and returns ErrRefreshTokenReused.
+	RotateRefreshToken(ctx context.Context, hash string, next *models.RefreshToken) (*models.RefreshToken, error)
+
+	// RevokeRefreshTokenFamily revokes every token issued from the same
+	// login as the token identified by hash.
+	RevokeRefreshTokenFamily(ctx context.Context, hash string) error
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/google/uuid"
+)
+
+type MenuService interface {
+	// GetMenu returns the active sections and pages the role may view.
+	GetMenu(ctx context.Context, roleId uuid.UUID) ([]dto.MenuSectionDTO, error)
+}
+
+type MenuRepository interface {
+	// GetActiveRows returns active pages of active sections ordered by
+	// section_order then page_order.
+	GetActiveRows(ctx context.Context) ([]dto.MenuRowDTO, error)
+}
@@ -0,0 +1,41 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/apperrors"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPageNotFound  = apperrors.Wrap(apperrors.NotFound, "page not found", ErrNotFound)
+	ErrPagePathTaken = apperrors.New(apperrors.Conflict, "page path is already in use")
+)
+
+type PageService interface {
+	Create(ctx context.Context, sectionId uuid.UUID, data dto.PageRequestDTO) (uuid.UUID, error)
+	GetBySection(ctx context.Context, sectionId uuid.UUID) ([]dto.PageResponseDTO, error)
+	FindOne(ctx context.Context, id uuid.UUID) (*dto.PageResponseDTO, error)
+	Update(ctx context.Context, id uuid.UUID, data dto.PageRequestDTO) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Reorder(ctx context.Context, sectionId uuid.UUID, data dto.ReorderDTO) error
+	Move(ctx context.Context, id uuid.UUID, data dto.PageMoveDTO) error
+}
+
+type PageRepository interface {
+	// Create appends the page to the section when no page_order is given.
+	// Callers run it in a transaction together with their path check.
+	Create(ctx context.Context, sectionId uuid.UUID, data dto.PageRequestDTO) (uuid.UUID, error)
+	GetBySection(ctx context.Context, sectionId uuid.UUID) ([]dto.PageResponseDTO, error)
+	FindOne(ctx context.Context, id uuid.UUID) (*dto.PageResponseDTO, error)
+	Update(ctx context.Context, id uuid.UUID, data dto.PageRequestDTO) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Reorder rewrites every listed page_order of the section in one
+	// transaction and fails as a whole if any page is not in the section.
+	Reorder(ctx context.Context, sectionId uuid.UUID, data dto.ReorderDTO) error
+	// Move places the page into another section, appending it when no
+	// page_order is given.
+	Move(ctx context.Context, id uuid.UUID, data dto.PageMoveDTO) error
+	ExistsByPath(ctx context.Context, path string, excludeId uuid.UUID) (bool, error)
+}
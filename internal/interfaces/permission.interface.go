@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/google/uuid"
+)
+
+type PermissionService interface {
+	HasPermission(ctx context.Context, roleId uuid.UUID, page, action string) (bool, error)
+	// Invalidate drops the cached permissions of a role after it changes.
+	Invalidate(roleId uuid.UUID)
+}
+
+type PermissionRepository interface {
+	// FindRolePermissions returns nil without an error when the role does
+	// not exist.
+	FindRolePermissions(ctx context.Context, roleId uuid.UUID) (*dto.RolePermissionsDTO, error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import "github.com/chand-magar/SolidBaseGoStructure/internal/apperrors"
+
+var (
+	// ErrNotFound is wrapped by every entity's not-found error, so callers
+	// can match any of them with errors.Is.
+	ErrNotFound = apperrors.New(apperrors.NotFound, "not found")
+	// ErrNoFieldsToUpdate is returned by updates that were given nothing to
+	// change.
+	ErrNoFieldsToUpdate = apperrors.New(apperrors.Validation, "no fields provided for update")
+)
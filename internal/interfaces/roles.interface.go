@@ -0,0 +1,41 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/apperrors"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRoleNotFound  = apperrors.Wrap(apperrors.NotFound, "role not found", ErrNotFound)
+	ErrRoleNameTaken = apperrors.New(apperrors.Conflict, "role name is already in use")
+	ErrRoleInUse     = apperrors.New(apperrors.Conflict, "role is still assigned to active users")
+	ErrRoleStatusSet = apperrors.New(apperrors.Validation, "status cannot be changed by a role update, use PATCH /roles/:id/status")
+)
+
+type RoleService interface {
+	Create(ctx context.Context, data dto.RoleRequestDTO) (uuid.UUID, error)
+	GetAll(ctx context.Context, params dto.PaginationParams) (dto.PageResult[dto.RoleResponseDTO], error)
+	FindOne(ctx context.Context, id uuid.UUID) (*dto.RoleResponseDTO, error)
+	FindByName(ctx context.Context, name string) (*dto.RoleResponseDTO, error)
+	Update(ctx context.Context, id uuid.UUID, data dto.RoleRequestDTO) error
+	Clone(ctx context.Context, id uuid.UUID, data dto.RoleCloneDTO) (uuid.UUID, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, data dto.RoleStatusDTO) error
+}
+
+type RoleRepository interface {
+	Create(ctx context.Context, data dto.RoleRequestDTO) (uuid.UUID, error)
+	List(ctx context.Context, params dto.PaginationParams) (dto.PageResult[dto.RoleResponseDTO], error)
+	FindOne(ctx context.Context, id uuid.UUID) (*dto.RoleResponseDTO, error)
+	// FindByName matches case-insensitively and ignores deleted roles.
+	FindByName(ctx context.Context, name string) (*dto.RoleResponseDTO, error)
+	Update(ctx context.Context, id uuid.UUID, data dto.RoleRequestDTO) error
+	// UpdateStatus refuses with ErrRoleInUse to move a role that still has
+	// active users away from Active. It locks the role while checking, so
+	// callers run it in a transaction.
+	UpdateStatus(ctx context.Context, id uuid.UUID, status models.StatusEnum) error
+	ExistsByName(ctx context.Context, name string, excludeId uuid.UUID) (bool, error)
+}
@@ -0,0 +1,35 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/apperrors"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSectionNotFound  = apperrors.Wrap(apperrors.NotFound, "section not found", ErrNotFound)
+	ErrSectionPathTaken = apperrors.New(apperrors.Conflict, "section path is already in use")
+)
+
+type SectionService interface {
+	Create(ctx context.Context, data dto.SectionRequestDTO) (uuid.UUID, error)
+	GetAll(ctx context.Context, params dto.PaginationParams) (dto.PageResult[dto.SectionResponseDTO], error)
+	FindOne(ctx context.Context, id uuid.UUID) (*dto.SectionResponseDTO, error)
+	Update(ctx context.Context, id uuid.UUID, data dto.SectionRequestDTO) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Reorder(ctx context.Context, data dto.ReorderDTO) error
+}
+
+type SectionRepository interface {
+	Create(ctx context.Context, data dto.SectionRequestDTO) (uuid.UUID, error)
+	List(ctx context.Context, params dto.PaginationParams) (dto.PageResult[dto.SectionResponseDTO], error)
+	FindOne(ctx context.Context, id uuid.UUID) (*dto.SectionResponseDTO, error)
+	Update(ctx context.Context, id uuid.UUID, data dto.SectionRequestDTO) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Reorder rewrites every listed section_order in one transaction and
+	// fails as a whole if any section is unknown.
+	Reorder(ctx context.Context, data dto.ReorderDTO) error
+	ExistsByPath(ctx context.Context, path string, excludeId uuid.UUID) (bool, error)
+}
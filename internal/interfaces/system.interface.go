@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+)
+
+type SystemService interface {
+	DatabaseStats(ctx context.Context) (*dto.DatabaseStatsDTO, error)
+}
+
+type SystemRepository interface {
+	// DatabaseStats pings the database and reads the pool counters. A
+	// failed ping is reported in the result rather than as an error.
+	DatabaseStats(ctx context.Context) (*dto.DatabaseStatsDTO, error)
+}
@@ -0,0 +1,36 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/apperrors"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+)
+
+var (
+	ErrTenantNotFound    = apperrors.Wrap(apperrors.NotFound, "tenant not found", ErrNotFound)
+	ErrTenantSlugTaken   = apperrors.New(apperrors.Conflict, "tenant slug is already in use")
+	ErrInvalidTenantSlug = apperrors.New(apperrors.Validation, "tenant slug must be 2-20 lowercase letters, digits or hyphens and start with a letter")
+)
+
+type TenantService interface {
+	// Create provisions the tenant's schema and then registers it.
+	Create(ctx context.Context, data dto.TenantRequestDTO) (*dto.TenantDTO, error)
+	GetAll(ctx context.Context) ([]dto.TenantDTO, error)
+	// Resolve returns the active tenant with slug, or ErrTenantNotFound.
+	Resolve(ctx context.Context, slug string) (*dto.TenantDTO, error)
+}
+
+type TenantRepository interface {
+	Create(ctx context.Context, data dto.TenantDTO) error
+	GetAll(ctx context.Context) ([]dto.TenantDTO, error)
+	// FindBySlug returns nil without an error when no tenant has slug.
+	FindBySlug(ctx context.Context, slug string) (*dto.TenantDTO, error)
+	ExistsBySlug(ctx context.Context, slug string) (bool, error)
+}
+
+// TenantProvisioner creates a tenant schema and applies the tenant
+// migrations to it. It is safe to run again on an existing schema.
+type TenantProvisioner interface {
+	Provision(ctx context.Context, schema string) error
+}
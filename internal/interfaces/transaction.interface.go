@@ -0,0 +1,10 @@
+package interfaces
+
+import "context"
+
+// Transactor runs fn in a database transaction. Repositories called with
+// the ctx handed to fn join that transaction; calling Do again inside fn
+// opens a savepoint that rolls back on its own when the inner fn fails.
+type Transactor interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
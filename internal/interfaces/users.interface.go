@@ -1,20 +1,54 @@
 package interfaces
 
 import (
+	"context"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/apperrors"
 	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
 	"github.com/google/uuid"
 )
 
+var (
+	ErrUserNotFound  = apperrors.Wrap(apperrors.NotFound, "user not found", ErrNotFound)
+	ErrInvalidCursor = apperrors.New(apperrors.Validation, "cursor is invalid for this listing")
+	ErrUnknownRole   = apperrors.New(apperrors.Validation, "role_id does not match an active role")
+)
+
 type UserService interface {
-	Create(data dto.RequestDTO) (uuid.UUID, error)
-	GetAll(params dto.PaginationParams) ([]dto.ResponseDTO, int64, int, error)
-	FindOne(id uuid.UUID) (*dto.ResponseDTO, error)
-	Update(id uuid.UUID, data dto.RequestDTO) error
+	Create(ctx context.Context, data dto.RequestDTO) (uuid.UUID, error)
+	GetAll(ctx context.Context, params dto.PaginationParams) (dto.PageResult[dto.ResponseDTO], error)
+	// GetPage lists users by keyset pagination, for params.UseCursor.
+	GetPage(ctx context.Context, params dto.PaginationParams) ([]dto.ResponseDTO, *dto.CursorPage, error)
+	FindOne(ctx context.Context, id uuid.UUID) (*dto.ResponseDTO, error)
+	Update(ctx context.Context, id uuid.UUID, data dto.RequestDTO) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	ResetPassword(ctx context.Context, username, password string) error
+	// Purge permanently removes users soft-deleted longer than the
+	// configured retention period and returns how many were removed.
+	Purge(ctx context.Context) (int64, error)
 }
 
 type UserRepository interface {
-	Create(user dto.RequestDTO) (uuid.UUID, error)
-	GetAll(params dto.PaginationParams) ([]dto.ResponseDTO, int64, error)
-	FindOne(id uuid.UUID) (*dto.ResponseDTO, error)
-	Update(id uuid.UUID, data dto.RequestDTO) error
+	Create(ctx context.Context, user dto.RequestDTO) (uuid.UUID, error)
+	List(ctx context.Context, params dto.PaginationParams) (dto.PageResult[dto.ResponseDTO], error)
+	// GetPage returns the page after (or before) params.Cursor and the
+	// cursors of its neighbours, or ErrInvalidCursor.
+	GetPage(ctx context.Context, params dto.PaginationParams) ([]dto.ResponseDTO, *dto.CursorPage, error)
+	FindOne(ctx context.Context, id uuid.UUID) (*dto.ResponseDTO, error)
+	// Update changes a user that is not deleted, including its username and
+	// password. A status change applies to its credential too; a new
+	// password or deactivation revokes its refresh tokens.
+	Update(ctx context.Context, id uuid.UUID, data dto.RequestDTO) error
+	// SoftDelete marks the user and its credential as Deleted, keeping the
+	// credential's status for Restore, and revokes its refresh tokens.
+	SoftDelete(ctx context.Context, id uuid.UUID) error
+	// Restore gives a deleted user's credential back the status it had,
+	// Inactive when that is unknown; the profile follows the credential.
+	Restore(ctx context.Context, id uuid.UUID) error
+	// ResetPassword replaces the password of a credential that is not
+	// deleted and revokes the user's refresh tokens.
+	ResetPassword(ctx context.Context, username, password string) error
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
 }
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const PrincipalKey = "principal"
+
+// Authenticate rejects requests without a valid bearer token, or with one
+// issued for a different tenant than the request resolved to, and stores the
+// resolved principal on both the gin.Context and the request context.
+func Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		header := c.GetHeader("Authorization")
+		scheme, tokenString, found := strings.Cut(header, " ")
+
+		if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(tokenString) == "" {
+			abortUnauthorized(c, "missing bearer token")
+			return
+		}
+
+		claims, err := utils.VerifyToken(strings.TrimSpace(tokenString))
+		if err != nil {
+			abortUnauthorized(c, "invalid or expired token")
+			return
+		}
+
+		tenantSlug := ""
+		if tenant, ok := utils.TenantFromContext(c.Request.Context()); ok {
+			tenantSlug = tenant.Slug
+		}
+		if claims.Tenant != tenantSlug {
+			abortUnauthorized(c, "token was issued for another tenant")
+			return
+		}
+
+		principal := &dto.Principal{
+			ProfileNo: claims.ProfileNo,
+			ProfileId: claims.ProfileId,
+			RoleId:    claims.RoleId,
+			Role:      claims.Role,
+		}
+
+		c.Set(PrincipalKey, principal)
+		c.Request = c.Request.WithContext(utils.WithPrincipal(c.Request.Context(), principal))
+
+		c.Next()
+	}
+}
+
+// GetPrincipal returns the principal set by Authenticate.
+func GetPrincipal(c *gin.Context) (*dto.Principal, bool) {
+	value, exists := c.Get(PrincipalKey)
+	if !exists {
+		return nil, false
+	}
+	principal, ok := value.(*dto.Principal)
+	return principal, ok
+}
+
+func abortUnauthorized(c *gin.Context, detail string) {
+	c.Header("WWW-Authenticate", `Bearer realm="api"`)
+	utils.AbortWithProblem(c, http.StatusUnauthorized, detail)
+}
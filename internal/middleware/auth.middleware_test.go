@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func initTestJWT(t *testing.T, accessTokenTTL time.Duration) {
+	t.Helper()
+
+	err := utils.InitJWT(config.JWT{
+		Issuer:          "test",
+		Audience:        "test-api",
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: time.Hour,
+		SigningKid:      "test",
+		Keys:            []config.SigningKey{{Kid: "test", Algorithm: "HS256", Secret: strings.Repeat("s", 32)}},
+	})
+	if err != nil {
+		t.Fatalf("InitJWT() error = %v", err)
+	}
+}
+
+func createTestToken(t *testing.T, claims utils.UserClaims) string {
+	t.Helper()
+
+	token, err := utils.CreateToken(claims)
+	if err != nil {
+		t.Fatalf("CreateToken() error = %v", err)
+	}
+	return token
+}
+
+func TestAuthenticate(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	initTestJWT(t, -time.Minute)
+	expired := createTestToken(t, utils.UserClaims{ProfileNo: 7})
+
+	initTestJWT(t, time.Minute)
+
+	claims := utils.UserClaims{ProfileNo: 7, ProfileId: uuid.New(), RoleId: uuid.New(), Role: "Editors"}
+	valid := createTestToken(t, claims)
+
+	acmeClaims := claims
+	acmeClaims.Tenant = "acme"
+	acme := createTestToken(t, acmeClaims)
+
+	acmeTenant := &dto.TenantDTO{Slug: "acme", SchemaName: "tenant_acme"}
+
+	tests := []struct {
+		name          string
+		authorization string
+		tenant        *dto.TenantDTO
+		status        int
+	}{
+		{"no header", "", nil, http.StatusUnauthorized},
+		{"other scheme", "Basic " + valid, nil, http.StatusUnauthorized},
+		{"empty token", "Bearer  ", nil, http.StatusUnauthorized},
+		{"malformed token", "Bearer not-a-token", nil, http.StatusUnauthorized},
+		{"tampered token", "Bearer " + valid[:len(valid)-2] + "xx", nil, http.StatusUnauthorized},
+		{"expired token", "Bearer " + expired, nil, http.StatusUnauthorized},
+		{"token of a tenant on the default tenant", "Bearer " + acme, nil, http.StatusUnauthorized},
+		{"default token on a tenant", "Bearer " + valid, acmeTenant, http.StatusUnauthorized},
+		{"valid token", "Bearer " + valid, nil, http.StatusOK},
+		{"scheme is case-insensitive", "bearer " + valid, nil, http.StatusOK},
+		{"valid tenant token", "Bearer " + acme, acmeTenant, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			var fromGin, fromRequest *dto.Principal
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.tenant != nil {
+					c.Request = c.Request.WithContext(utils.WithTenant(c.Request.Context(), tt.tenant))
+				}
+			})
+			router.Use(Authenticate())
+			router.GET("/", func(c *gin.Context) {
+				fromGin, _ = GetPrincipal(c)
+				fromRequest, _ = utils.PrincipalFromContext(c.Request.Context())
+			})
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				request.Header.Set("Authorization", tt.authorization)
+			}
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.status)
+			}
+
+			if tt.status == http.StatusUnauthorized {
+				if recorder.Header().Get("WWW-Authenticate") == "" {
+					t.Errorf("401 without a WWW-Authenticate header")
+				}
+				return
+			}
+
+			want := dto.Principal{ProfileNo: claims.ProfileNo, ProfileId: claims.ProfileId, RoleId: claims.RoleId, Role: claims.Role}
+			if fromGin == nil || *fromGin != want {
+				t.Errorf("GetPrincipal() = %+v, want %+v", fromGin, want)
+			}
+			if fromRequest != fromGin {
+				t.Errorf("request context principal = %+v, want the gin one", fromRequest)
+			}
+		})
+	}
+}
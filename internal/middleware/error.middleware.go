@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/apperrors"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// statusClientClosedRequest is the nginx status for a request whose client
+// went away before the response was written.
+const statusClientClosedRequest = 499
+
+// HandleErrors answers requests whose handler attached an error with
+// c.Error instead of writing a response. The status code comes from the
+// error's domain kind; errors of no known kind are logged and answered with
+// a generic 500, so driver and SQL messages never reach the client. Requests
+// the client canceled are answered with 499 and not logged as failures.
+func HandleErrors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+
+		if errors.Is(err, context.Canceled) && apperrors.KindOf(err) == apperrors.Internal {
+			utils.AbortWithProblem(c, statusClientClosedRequest, "Request was canceled by the client")
+			return
+		}
+
+		kind := apperrors.KindOf(err)
+
+		switch kind {
+		case apperrors.Internal:
+			slog.ErrorContext(c.Request.Context(), "request failed",
+				slog.String("request_id", utils.RequestIdFromContext(c.Request.Context())),
+				slog.String("method", c.Request.Method), slog.String("path", c.FullPath()), slog.Any("error", err))
+			utils.AbortWithProblem(c, kind.Status(), "Internal server error")
+			return
+		case apperrors.Unavailable:
+			slog.WarnContext(c.Request.Context(), "request failed, dependency unavailable",
+				slog.String("request_id", utils.RequestIdFromContext(c.Request.Context())),
+				slog.String("method", c.Request.Method), slog.String("path", c.FullPath()),
+				slog.Any("error", err), slog.Any("cause", apperrors.CauseOf(err)))
+		}
+
+		utils.AbortWithProblem(c, kind.Status(), apperrors.MessageOf(err))
+	}
+}
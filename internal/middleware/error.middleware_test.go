@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/apperrors"
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandleErrors(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"domain error", apperrors.New(apperrors.NotFound, "role not found"), http.StatusNotFound},
+		{"unavailable", apperrors.Wrap(apperrors.Unavailable, "database did not respond in time", context.DeadlineExceeded), http.StatusServiceUnavailable},
+		{"unknown error", errors.New("boom"), http.StatusInternalServerError},
+		{"client canceled", fmt.Errorf("query: %w", context.Canceled), statusClientClosedRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			router := gin.New()
+			router.Use(HandleErrors())
+			router.GET("/", func(c *gin.Context) { c.Error(tt.err) })
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if recorder.Code != tt.status {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.status)
+			}
+		})
+	}
+}
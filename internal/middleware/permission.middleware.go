@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type Authorizer struct {
+	Service interfaces.PermissionService
+}
+
+func NewAuthorizer(service interfaces.PermissionService) *Authorizer {
+	return &Authorizer{Service: service}
+}
+
+// RequirePermission allows the request only when the caller's role grants
+// action on page. It must run after Authenticate.
+func (a *Authorizer) RequirePermission(page, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		principal, ok := GetPrincipal(c)
+		if !ok {
+			abortUnauthorized(c, "missing bearer token")
+			return
+		}
+
+		allowed, err := a.Service.HasPermission(c.Request.Context(), principal.RoleId, page, action)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		if !allowed {
+			utils.AbortWithProblem(c, http.StatusForbidden, fmt.Sprintf("%s permission on %s is required", action, page))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/gin-gonic/gin"
+)
+
+// PinPrimaryForWrites sends every query of a request that may write to the
+// primary, so reads made after a write in the same request see it. Safe
+// methods keep reading from replicas.
+func PinPrimaryForWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			c.Request = c.Request.WithContext(database.PinPrimary(c.Request.Context()))
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"regexp"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const RequestIdHeader = "X-Request-ID"
+
+// requestIdPattern limits client-supplied IDs to short tokens that are safe
+// to echo in headers and logs.
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// RequestID gives every request a correlation ID: the caller's X-Request-ID
+// when it is well formed, else a new UUID. The ID is echoed in the response
+// header and stored on the request context for logs and error responses.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		requestId := c.GetHeader(RequestIdHeader)
+		if !requestIdPattern.MatchString(requestId) {
+			requestId = uuid.NewString()
+		}
+
+		c.Header(RequestIdHeader, requestId)
+		c.Request = c.Request.WithContext(utils.WithRequestId(c.Request.Context(), requestId))
+
+		c.Next()
+	}
+}
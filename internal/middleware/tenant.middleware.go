@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const TenantKey = "tenant"
+
+// ResolveTenant scopes the request to the tenant named by the configured
+// header, the subdomain of the base domain or the bearer token's tenant
+// claim, in that order. The claim is read unverified; Authenticate verifies
+// the token and rejects it when it belongs to another tenant. Requests that
+// name no tenant stay on the default one.
+func ResolveTenant(tenants interfaces.TenantService, cfg config.Tenancy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		slug := requestedTenant(c, cfg)
+		if slug == "" {
+			c.Next()
+			return
+		}
+
+		tenant, err := tenants.Resolve(c.Request.Context(), slug)
+		if errors.Is(err, interfaces.ErrTenantNotFound) {
+			utils.AbortWithProblem(c, http.StatusNotFound, fmt.Sprintf("Unknown tenant %q", slug))
+			return
+		}
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Set(TenantKey, tenant)
+		c.Request = c.Request.WithContext(utils.WithTenant(c.Request.Context(), tenant))
+
+		c.Next()
+	}
+}
+
+// DefaultTenantOnly keeps endpoints that expose or change what every tenant
+// shares, such as the connection pools and the sections and pages of the
+// menu, away from tenant requests.
+func DefaultTenantOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		if database.TenantSchema(c.Request.Context()) != database.DefaultSchema {
+			utils.AbortWithProblem(c, http.StatusForbidden, "This API is only available to the default tenant")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func requestedTenant(c *gin.Context, cfg config.Tenancy) string {
+
+	if cfg.Header != "" {
+		if slug := strings.TrimSpace(c.GetHeader(cfg.Header)); slug != "" {
+			return slug
+		}
+	}
+
+	if cfg.BaseDomain != "" {
+		host := c.Request.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if sub, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(cfg.BaseDomain)); ok && !strings.Contains(sub, ".") {
+			return sub
+		}
+	}
+
+	scheme, token, found := strings.Cut(c.GetHeader("Authorization"), " ")
+	if found && strings.EqualFold(scheme, "Bearer") {
+		return utils.TenantClaim(strings.TrimSpace(token))
+	}
+
+	return ""
+}
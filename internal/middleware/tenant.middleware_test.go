@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// fakeTenantService resolves the tenants it holds by slug.
+type fakeTenantService struct {
+	tenants  map[string]*dto.TenantDTO
+	resolved []string
+}
+
+func (s *fakeTenantService) Create(ctx context.Context, data dto.TenantRequestDTO) (*dto.TenantDTO, error) {
+	return nil, nil
+}
+
+func (s *fakeTenantService) GetAll(ctx context.Context) ([]dto.TenantDTO, error) {
+	return nil, nil
+}
+
+func (s *fakeTenantService) Resolve(ctx context.Context, slug string) (*dto.TenantDTO, error) {
+	s.resolved = append(s.resolved, slug)
+	if tenant, ok := s.tenants[slug]; ok {
+		return tenant, nil
+	}
+	return nil, interfaces.ErrTenantNotFound
+}
+
+func TestResolveTenant(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	initTestJWT(t, time.Minute)
+	globexToken := createTestToken(t, utils.UserClaims{ProfileNo: 1, Tenant: "globex"})
+	defaultToken := createTestToken(t, utils.UserClaims{ProfileNo: 1})
+
+	cfg := config.Tenancy{Header: "X-Tenant", BaseDomain: "example.com"}
+
+	tests := []struct {
+		name    string
+		host    string
+		headers map[string]string
+		status  int
+		schema  string
+	}{
+		{"no tenant named", "api.test", nil, http.StatusOK, database.DefaultSchema},
+		{"header", "api.test", map[string]string{"X-Tenant": " acme "}, http.StatusOK, "tenant_acme"},
+		{"subdomain", "acme.example.com", nil, http.StatusOK, "tenant_acme"},
+		{"subdomain with port and capitals", "ACME.Example.com:8080", nil, http.StatusOK, "tenant_acme"},
+		{"nested subdomain is ignored", "www.acme.example.com", nil, http.StatusOK, database.DefaultSchema},
+		{"base domain alone", "example.com", nil, http.StatusOK, database.DefaultSchema},
+		{"bearer claim", "api.test", map[string]string{"Authorization": "Bearer " + globexToken}, http.StatusOK, "tenant_globex"},
+		{"bearer without a claim", "api.test", map[string]string{"Authorization": "Bearer " + defaultToken}, http.StatusOK, database.DefaultSchema},
+		{"header wins over subdomain", "acme.example.com", map[string]string{"X-Tenant": "globex"}, http.StatusOK, "tenant_globex"},
+		{"subdomain wins over claim", "acme.example.com", map[string]string{"Authorization": "Bearer " + globexToken}, http.StatusOK, "tenant_acme"},
+		{"unknown tenant", "api.test", map[string]string{"X-Tenant": "initech"}, http.StatusNotFound, ""},
+		{"unknown subdomain", "initech.example.com", nil, http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			tenants := &fakeTenantService{tenants: map[string]*dto.TenantDTO{
+				"acme":   {Slug: "acme", SchemaName: "tenant_acme"},
+				"globex": {Slug: "globex", SchemaName: "tenant_globex"},
+			}}
+
+			schema := ""
+
+			router := gin.New()
+			router.Use(ResolveTenant(tenants, cfg))
+			router.GET("/", func(c *gin.Context) {
+				schema = database.TenantSchema(c.Request.Context())
+			})
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.Host = tt.host
+			for name, value := range tt.headers {
+				request.Header.Set(name, value)
+			}
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.status)
+			}
+			if schema != tt.schema {
+				t.Errorf("schema = %q, want %q", schema, tt.schema)
+			}
+			if tt.schema == database.DefaultSchema && len(tenants.resolved) != 0 {
+				t.Errorf("resolved %v for a request that names no tenant", tenants.resolved)
+			}
+		})
+	}
+}
+
+func TestDefaultTenantOnly(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		tenant *dto.TenantDTO
+		status int
+	}{
+		{"default tenant", nil, http.StatusOK},
+		{"default tenant by name", &dto.TenantDTO{Slug: "master", SchemaName: database.DefaultSchema}, http.StatusOK},
+		{"other tenant", &dto.TenantDTO{Slug: "acme", SchemaName: "tenant_acme"}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			reached := false
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.tenant != nil {
+					c.Request = c.Request.WithContext(utils.WithTenant(c.Request.Context(), tt.tenant))
+				}
+			})
+			router.Use(DefaultTenantOnly())
+			router.POST("/", func(c *gin.Context) { reached = true })
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", nil))
+
+			if recorder.Code != tt.status {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.status)
+			}
+			if reached != (tt.status == http.StatusOK) {
+				t.Errorf("handler reached = %v", reached)
+			}
+		})
+	}
+}
@@ -13,10 +13,13 @@ type UsersCredentials struct {
 	Username     string     `json:"username" gorm:"type:varchar(65);index"`
 	Password     string     `json:"password" gorm:"type:varchar(255);index"`
 	Status       StatusEnum `json:"status" gorm:"type:status_enum;default:'I';index"`
-	CreatedAt    time.Time  `json:"created_at" gorm:"index;default:NULL"`
-	CreatedBy    uint32     `json:"created_by" gorm:"index;default:NULL"`
-	UpdatedAt    time.Time  `json:"updated_at" gorm:"index;default:NULL"`
-	UpdatedBy    uint32     `json:"updated_by" gorm:"index;default:NULL"`
+	// RestoreStatus is the status to go back to when a deleted user is
+	// restored.
+	RestoreStatus *StatusEnum `json:"-" gorm:"type:status_enum;default:NULL"`
+	CreatedAt     time.Time   `json:"created_at" gorm:"index;default:NULL"`
+	CreatedBy     uint32      `json:"created_by" gorm:"index;default:NULL"`
+	UpdatedAt     time.Time   `json:"updated_at" gorm:"index;default:NULL"`
+	UpdatedBy     uint32      `json:"updated_by" gorm:"index;default:NULL"`
 }
 
 // TableName specifies the custom table name for the User model
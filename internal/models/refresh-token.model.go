@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken stores the SHA-256 hash of an opaque refresh token. Tokens
+// issued by rotating one another share a FamilyId so a replayed token can
+// revoke the whole chain.
+type RefreshToken struct {
+	TokenNo   uint32     `json:"token_no" gorm:"primaryKey;autoIncrement;"`
+	TokenId   uuid.UUID  `json:"token_id" gorm:"type:uuid;index"`
+	FamilyId  uuid.UUID  `json:"family_id" gorm:"type:uuid;index"`
+	ProfileNo uint32     `json:"profile_no" gorm:"index"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);uniqueIndex"`
+	UserAgent string     `json:"user_agent" gorm:"type:varchar(255);default:NULL"`
+	IpAddress string     `json:"ip_address" gorm:"type:varchar(45);default:NULL"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"index"`
+	UsedAt    *time.Time `json:"used_at" gorm:"default:NULL"`
+	RevokedAt *time.Time `json:"revoked_at" gorm:"index;default:NULL"`
+	CreatedAt time.Time  `json:"created_at" gorm:"index;default:NULL"`
+}
+
+// TableName specifies the custom table name for the RefreshToken model
+func (RefreshToken) TableName() string {
+	return "master.refresh_tokens"
+}
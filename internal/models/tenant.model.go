@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tenant is a row of the tenant registry. Each tenant keeps its users,
+// credentials, roles and refresh tokens in its own schema, SchemaName.
+type Tenant struct {
+	TenantNo   uint16     `json:"tenant_no" gorm:"primary_key; autoIncrement;"`
+	TenantId   uuid.UUID  `json:"tenant_id" gorm:"type:uuid;index;"`
+	Slug       string     `json:"slug" gorm:"type:varchar(30);uniqueIndex"`
+	TenantName string     `json:"tenant_name" gorm:"type:varchar(65)"`
+	SchemaName string     `json:"schema_name" gorm:"type:varchar(63);uniqueIndex"`
+	Status     StatusEnum `json:"status" gorm:"type:status_enum;default:'A';index"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"index;default:NULL"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"index;default:NULL"`
+}
+
+// TableName specifies the custom table name for the Tenant model
+func (Tenant) TableName() string {
+	return "master.tenants"
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const __REFRESH_TOKEN_TBL__ = "refresh_tokens"
+
+type authRepo struct {
+	db *gorm.DB
+}
+
+func NewAuthRepository(db *gorm.DB) interfaces.AuthRepository {
+	return &authRepo{db: db}
+}
+
+func (r *authRepo) FindCredentialByUsername(ctx context.Context, username string) (*dto.CredentialDTO, error) {
+	return r.findCredential(ctx, "credential.username = ?", username)
+}
+
+func (r *authRepo) FindCredentialByProfileNo(ctx context.Context, profileNo uint32) (*dto.CredentialDTO, error) {
+	return r.findCredential(ctx, "credential.profile_no = ?", profileNo)
+}
+
+func (r *authRepo) findCredential(ctx context.Context, condition string, arg interface{}) (*dto.CredentialDTO, error) {
+
+	var cred dto.CredentialDTO
+
+	query := fmt.Sprintf(`
+		SELECT credential.credential_no,
+			credential.profile_no,
+			credential.username,
+			credential.password,
+			credential.status,
+			profile.profile_id,
+			role.role_id,
+			role.role_name
+		FROM %s AS credential
+
+	INNER JOIN %s AS profile
+		ON profile.profile_no = credential.profile_no
+
+	INNER JOIN %s AS role
+		ON role.role_no = profile.role_no
+
+	WHERE %s
+		LIMIT 1`, database.Table(ctx, __CREDENTIAL_TBL__), database.Table(ctx, __PROFILE_TBL__), database.Table(ctx, __ROLE_TBL__), condition)
+
+	result := database.Conn(ctx, r.db).Raw(query, arg).Scan(&cred)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &cred, nil
+}
+
+func (r *authRepo) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	return database.Conn(ctx, r.db).Table(database.Table(ctx, __REFRESH_TOKEN_TBL__)).Create(token).Error
+}
+
+func (r *authRepo) RotateRefreshToken(ctx context.Context, hash string, next *models.RefreshToken) (*models.RefreshToken, error) {
+
+	var current models.RefreshToken
+	reused := false
+
+	table := database.Table(ctx, __REFRESH_TOKEN_TBL__)
+
+	err := database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		result := tx.Table(table).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", hash).
+			Limit(1).
+			Find(&current)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return interfaces.ErrInvalidRefreshToken
+		}
+
+		now := time.Now().UTC()
+
+		// A token that was already exchanged is being replayed: assume it was
+		// stolen and revoke every token descended from the same login.
+		if current.UsedAt != nil {
+			reused = true
+			return tx.Table(table).
+				Where("family_id = ? AND revoked_at IS NULL", current.FamilyId).
+				Update("revoked_at", now).Error
+		}
+
+		if current.RevokedAt != nil || now.After(current.ExpiresAt) {
+			return interfaces.ErrInvalidRefreshToken
+		}
+
+		if err := tx.Table(table).Where("token_no = ?", current.TokenNo).Update("used_at", now).Error; err != nil {
+			return err
+		}
+
+		next.FamilyId = current.FamilyId
+		next.ProfileNo = current.ProfileNo
+
+		return tx.Table(table).Create(next).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if reused {
+		return nil, interfaces.ErrRefreshTokenReused
+	}
+
+	return &current, nil
+}
+
+func (r *authRepo) RevokeRefreshTokenFamily(ctx context.Context, hash string) error {
+
+	query := fmt.Sprintf(`
+		UPDATE %s SET revoked_at = ?
+		WHERE revoked_at IS NULL
+			AND family_id = (SELECT family_id FROM %s WHERE token_hash = ? LIMIT 1)`, database.Table(ctx, __REFRESH_TOKEN_TBL__), database.Table(ctx, __REFRESH_TOKEN_TBL__))
+
+	return database.Conn(ctx, r.db).Exec(query, time.Now().UTC(), hash).Error
+}
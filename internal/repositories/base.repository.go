@@ -0,0 +1,448 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// tableSpec describes the table a baseRepo reads and writes.
+type tableSpec struct {
+	table string
+	// tenant tables are qualified with the request's tenant schema.
+	tenant bool
+	alias  string
+	// idColumn is the public UUID column matched by the *ByID methods.
+	idColumn string
+	// columns is the select list of every read, written against alias. It
+	// receives ctx so subqueries can name tenant tables.
+	columns func(ctx context.Context) string
+	// joins is added after FROM in reads; nil when there are none.
+	joins func(ctx context.Context) string
+	// notFound is returned when no row matches; it wraps ErrNotFound.
+	notFound error
+	// sortColumns whitelists the sort_by values accepted by List.
+	sortColumns map[string]string
+	defaultSort string
+	// searchColumns are matched with ILIKE against PaginationParams.Search.
+	searchColumns []string
+	// softDelete tables mark deleted rows with status 'D' instead of
+	// removing them. Reads and updates skip them; List shows them only
+	// when asked for that status.
+	softDelete bool
+}
+
+// filter is one extra condition of a read, written against the alias.
+type filter struct {
+	condition string
+	args      []interface{}
+}
+
+func filterBy(condition string, args ...interface{}) filter {
+	return filter{condition: condition, args: args}
+}
+
+// baseRepo implements the queries every entity repository shares; T is the
+// DTO reads are scanned into and Create writes from. Entity repositories
+// embed it, which provides their FindOne, List and Delete, and add what is
+// specific to them.
+type baseRepo[T any] struct {
+	db   *gorm.DB
+	spec tableSpec
+}
+
+func newBaseRepo[T any](db *gorm.DB, spec tableSpec) baseRepo[T] {
+	return baseRepo[T]{db: db, spec: spec}
+}
+
+// tableName returns the table qualified for the request.
+func (r *baseRepo[T]) tableName(ctx context.Context) string {
+	if r.spec.tenant {
+		return database.Table(ctx, r.spec.table)
+	}
+	return r.spec.table
+}
+
+func (r *baseRepo[T]) from(ctx context.Context) string {
+	from := fmt.Sprintf("%s AS %s", r.tableName(ctx), r.spec.alias)
+	if r.spec.joins != nil {
+		from += "\n" + r.spec.joins(ctx)
+	}
+	return from
+}
+
+// Insert adds a row with fields and its audit columns.
+func (r *baseRepo[T]) Insert(ctx context.Context, fields map[string]interface{}) error {
+
+	stampCreated(ctx, fields)
+
+	cols, vals, args := buildSQLParts(fields)
+	query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`, r.tableName(ctx), cols, vals)
+
+	return database.Conn(ctx, r.db).Exec(query, args...).Error
+}
+
+// Create inserts row through Insert. Its columns are the fields of T that
+// are set and writable; T marks computed fields read-only with gorm:"->".
+func (r *baseRepo[T]) Create(ctx context.Context, row *T) error {
+
+	fields, err := createFields(ctx, row, r.db.NamingStrategy)
+	if err != nil {
+		return err
+	}
+
+	return r.Insert(ctx, fields)
+}
+
+// FindOne returns the row with id, or the spec's not-found error.
+func (r *baseRepo[T]) FindOne(ctx context.Context, id uuid.UUID) (*T, error) {
+	return r.FindWhere(ctx, filterBy(r.spec.alias+"."+r.spec.idColumn+" = ?", id))
+}
+
+// FindWhere returns the first row matching every filter, or the spec's
+// not-found error.
+func (r *baseRepo[T]) FindWhere(ctx context.Context, filters ...filter) (*T, error) {
+
+	var item T
+
+	condition, args := joinFilters(r.liveFilters(filters))
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		%s
+		LIMIT 1`, r.spec.columns(ctx), r.from(ctx), condition)
+
+	result := database.Reader(ctx, r.db).Raw(query, args...).Scan(&item)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, r.spec.notFound
+	}
+
+	return &item, nil
+}
+
+// FindAll returns every row matching the filters in orderBy order.
+func (r *baseRepo[T]) FindAll(ctx context.Context, orderBy string, filters ...filter) ([]T, error) {
+
+	items := []T{}
+
+	condition, args := joinFilters(r.liveFilters(filters))
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		%s
+		ORDER BY %s`, r.spec.columns(ctx), r.from(ctx), condition, orderBy)
+
+	if err := database.Reader(ctx, r.db).Raw(query, args...).Scan(&items).Error; err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// List returns one page of the rows matching the search and status, sorted
+// by whitelisted columns.
+func (r *baseRepo[T]) List(ctx context.Context, params dto.PaginationParams) (dto.PageResult[T], error) {
+	return r.list(ctx, params)
+}
+
+// list is List with extra filters, for repositories whose listings take
+// more parameters.
+func (r *baseRepo[T]) list(ctx context.Context, params dto.PaginationParams, filters ...filter) (dto.PageResult[T], error) {
+
+	result := dto.PageResult[T]{Items: []T{}}
+
+	filters = r.listFilters(params, filters...)
+
+	total, err := r.count(ctx, filters)
+	if err != nil {
+		return result, err
+	}
+
+	condition, args := joinFilters(filters)
+
+	offset := (params.Page - 1) * params.Size
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		%s
+		ORDER BY %s
+		LIMIT ? OFFSET ?`, r.spec.columns(ctx), r.from(ctx), condition, r.orderBy(params))
+
+	if err := database.Reader(ctx, r.db).Raw(query, append(args, params.Size, offset)...).Scan(&result.Items).Error; err != nil {
+		return result, err
+	}
+
+	return dto.NewPageResult(result.Items, total, params.Size), nil
+}
+
+// listFilters adds the search and status conditions of params to filters.
+func (r *baseRepo[T]) listFilters(params dto.PaginationParams, filters ...filter) []filter {
+
+	if params.Search != "" && len(r.spec.searchColumns) > 0 {
+		search := "%" + params.Search + "%"
+		conditions := make([]string, 0, len(r.spec.searchColumns))
+		args := make([]interface{}, 0, len(r.spec.searchColumns))
+		for _, column := range r.spec.searchColumns {
+			conditions = append(conditions, column+" ILIKE ?")
+			args = append(args, search)
+		}
+		filters = append(filters, filterBy("("+strings.Join(conditions, " OR ")+")", args...))
+	}
+
+	if params.Status != "" {
+		return append(filters, filterBy(r.spec.alias+".status = ?", params.Status))
+	}
+
+	return r.liveFilters(filters)
+}
+
+// liveFilters adds the condition leaving out deleted rows of softDelete
+// tables to filters.
+func (r *baseRepo[T]) liveFilters(filters []filter) []filter {
+
+	if !r.spec.softDelete {
+		return filters
+	}
+
+	return append(filters, filterBy(r.spec.alias+".status <> ?", models.Deleted))
+}
+
+// count returns how many rows match the filters.
+func (r *baseRepo[T]) count(ctx context.Context, filters []filter) (int64, error) {
+
+	var total int64
+
+	condition, args := joinFilters(filters)
+
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s %s", r.from(ctx), condition)
+	if err := database.Reader(ctx, r.db).Raw(query, args...).Scan(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// orderBy turns params.Sort, or SortBy and Order when it is empty, into an
+// ORDER BY list through the spec's whitelist. It ends with the id column so
+// pages are stable when the sorted values tie.
+func (r *baseRepo[T]) orderBy(params dto.PaginationParams) string {
+
+	sort := params.Sort
+	if len(sort) == 0 {
+		sort = []dto.SortField{{Field: params.SortBy, Desc: params.Order == "DESC"}}
+	}
+
+	var terms []string
+
+	for _, field := range sort {
+		column, ok := r.spec.sortColumns[field.Field]
+		if !ok {
+			continue
+		}
+		direction := "ASC"
+		if field.Desc {
+			direction = "DESC"
+		}
+		terms = append(terms, column+" "+direction)
+	}
+
+	if len(terms) == 0 {
+		terms = append(terms, r.spec.sortColumns[r.spec.defaultSort]+" ASC")
+	}
+
+	return strings.Join(append(terms, r.spec.alias+"."+r.spec.idColumn+" ASC"), ", ")
+}
+
+// UpdateByID sets fields and the update audit columns on the row with id.
+// It returns ErrNoFieldsToUpdate for an empty update.
+func (r *baseRepo[T]) UpdateByID(ctx context.Context, id uuid.UUID, fields map[string]interface{}) error {
+	return r.updateWhere(ctx, fields, r.spec.idColumn+" = ?", id)
+}
+
+func (r *baseRepo[T]) updateWhere(ctx context.Context, fields map[string]interface{}, condition string, args ...interface{}) error {
+
+	if len(fields) == 0 {
+		return interfaces.ErrNoFieldsToUpdate
+	}
+
+	stampUpdated(ctx, fields)
+
+	if r.spec.softDelete {
+		condition = "(" + condition + ") AND status <> ?"
+		args = append(args, models.Deleted)
+	}
+
+	query, values := buildUpdateQuery(r.tableName(ctx), fields, condition, args...)
+
+	result := database.Conn(ctx, r.db).Exec(query, values...)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return r.spec.notFound
+	}
+
+	return nil
+}
+
+// SoftDelete marks the row with id of a softDelete table as Deleted. Like
+// every update it fails with the not-found error when the row is already
+// deleted.
+func (r *baseRepo[T]) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	return r.UpdateByID(ctx, id, map[string]interface{}{"status": models.Deleted})
+}
+
+// Delete removes the row with id for good.
+func (r *baseRepo[T]) Delete(ctx context.Context, id uuid.UUID) error {
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE %s = ?`, r.tableName(ctx), r.spec.idColumn)
+
+	result := database.Conn(ctx, r.db).Exec(query, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return r.spec.notFound
+	}
+
+	return nil
+}
+
+// Exists reports whether any row of the table itself, without the spec's
+// joins, matches condition.
+func (r *baseRepo[T]) Exists(ctx context.Context, condition string, args ...interface{}) (bool, error) {
+
+	var count int64
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s`, r.tableName(ctx), condition)
+	if err := database.Reader(ctx, r.db).Raw(query, args...).Scan(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func joinFilters(filters []filter) (string, []interface{}) {
+
+	if len(filters) == 0 {
+		return "", nil
+	}
+
+	conditions := make([]string, 0, len(filters))
+	var args []interface{}
+
+	for _, f := range filters {
+		conditions = append(conditions, f.condition)
+		args = append(args, f.args...)
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func buildSQLParts(fields map[string]interface{}) (columns string, values string, args []interface{}) {
+	i := 1
+	for col, val := range fields {
+		if i > 1 {
+			columns += ", "
+			values += ", "
+		}
+		columns += col
+		values += fmt.Sprintf("$%d", i)
+		args = append(args, val)
+		i++
+	}
+	return
+}
+
+// buildUpdateQuery renders an UPDATE of fields on table restricted by
+// condition, whose placeholders are bound to conditionArgs.
+func buildUpdateQuery(table string, fields map[string]interface{}, condition string, conditionArgs ...interface{}) (string, []interface{}) {
+	query := fmt.Sprintf("UPDATE %s SET ", table)
+	values := []interface{}{}
+	i := 0
+	for field, value := range fields {
+		if i > 0 {
+			query += ", "
+		}
+		query += field + " = ?"
+		values = append(values, value)
+		i++
+	}
+	query += " WHERE " + condition
+	values = append(values, conditionArgs...)
+	return query, values
+}
+
+// schemaCache holds the parsed GORM schemas of the types Create writes.
+var schemaCache sync.Map
+
+// createFields maps the set, writable fields of row to their columns.
+func createFields[T any](ctx context.Context, row *T, namer schema.Namer) (map[string]interface{}, error) {
+
+	rowSchema, err := schema.Parse(row, &schemaCache, namer)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]interface{}{}
+	value := reflect.ValueOf(row).Elem()
+
+	for _, field := range rowSchema.Fields {
+		if field.DBName == "" || !field.Creatable {
+			continue
+		}
+		if fieldValue, zero := field.ValueOf(ctx, value); !zero {
+			fields[field.DBName] = fieldValue
+		}
+	}
+
+	return fields, nil
+}
+
+// stampCreated sets the audit columns of a new row: created_at and
+// updated_at to now, created_by and updated_by to the principal ctx was
+// authenticated as. Writes without one, such as the admin CLI, leave the
+// author NULL.
+func stampCreated(ctx context.Context, fields map[string]interface{}) {
+
+	now := time.Now().UTC()
+	fields["created_at"] = now
+	fields["updated_at"] = now
+
+	if principal, ok := utils.PrincipalFromContext(ctx); ok {
+		fields["created_by"] = principal.ProfileNo
+		fields["updated_by"] = principal.ProfileNo
+	}
+}
+
+// stampUpdated sets updated_at to now and updated_by to the principal ctx
+// was authenticated as.
+func stampUpdated(ctx context.Context, fields map[string]interface{}) {
+
+	fields["updated_at"] = time.Now().UTC()
+
+	if principal, ok := utils.PrincipalFromContext(ctx); ok {
+		fields["updated_by"] = principal.ProfileNo
+	}
+}
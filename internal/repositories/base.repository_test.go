@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm/schema"
+)
+
+func TestCreateFields(t *testing.T) {
+
+	roleId := uuid.New()
+	role := dto.RoleResponseDTO{
+		RoleId:      roleId,
+		RoleName:    "Editors",
+		RoleDetails: dto.PermissionSet{},
+		Status:      models.Active,
+		ActiveUsers: 3,
+	}
+
+	fields, err := createFields(context.Background(), &role, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("createFields() error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"role_id":      roleId,
+		"role_name":    "Editors",
+		"role_details": dto.PermissionSet{},
+		"status":       models.Active,
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("createFields() = %v, want %v", fields, want)
+	}
+}
+
+func TestOrderBy(t *testing.T) {
+
+	repo := newBaseRepo[dto.ResponseDTO](nil, tableSpec{
+		alias:       "profile",
+		idColumn:    "profile_id",
+		sortColumns: dto.UserSortFields,
+		defaultSort: "user_full_name",
+	})
+
+	tests := []struct {
+		name   string
+		params dto.PaginationParams
+		want   string
+	}{
+		{
+			name:   "sort_by and order",
+			params: dto.PaginationParams{SortBy: "email_id", Order: "DESC"},
+			want:   "profile.email_id DESC, profile.profile_id ASC",
+		},
+		{
+			name:   "sort takes precedence",
+			params: dto.PaginationParams{SortBy: "email_id", Sort: []dto.SortField{{Field: "role_name"}, {Field: "created_at", Desc: true}}},
+			want:   "role.role_name ASC, profile.created_at DESC, profile.profile_id ASC",
+		},
+		{
+			name:   "unknown field falls back to the default",
+			params: dto.PaginationParams{SortBy: "password"},
+			want:   "profile.user_full_name ASC, profile.profile_id ASC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repo.orderBy(tt.params); got != tt.want {
+				t.Errorf("orderBy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListFilters(t *testing.T) {
+
+	spec := tableSpec{alias: "role", searchColumns: []string{"role.role_name"}}
+
+	tests := []struct {
+		name       string
+		softDelete bool
+		params     dto.PaginationParams
+		condition  string
+		args       []interface{}
+	}{
+		{
+			name:      "hard-deleted table",
+			params:    dto.PaginationParams{Search: "ed"},
+			condition: "WHERE (role.role_name ILIKE ?)",
+			args:      []interface{}{"%ed%"},
+		},
+		{
+			name:       "deleted rows are left out",
+			softDelete: true,
+			condition:  "WHERE role.status <> ?",
+			args:       []interface{}{models.Deleted},
+		},
+		{
+			name:       "deleted rows are listed when asked for",
+			softDelete: true,
+			params:     dto.PaginationParams{Status: "D"},
+			condition:  "WHERE role.status = ?",
+			args:       []interface{}{"D"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			spec.softDelete = tt.softDelete
+			repo := newBaseRepo[dto.RoleResponseDTO](nil, spec)
+
+			condition, args := joinFilters(repo.listFilters(tt.params))
+			if condition != tt.condition {
+				t.Errorf("condition = %q, want %q", condition, tt.condition)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %v, want %v", args, tt.args)
+			}
+		})
+	}
+}
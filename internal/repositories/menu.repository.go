@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"gorm.io/gorm"
+)
+
+type menuRepo struct {
+	db *gorm.DB
+}
+
+func NewMenuRepository(db *gorm.DB) interfaces.MenuRepository {
+	return &menuRepo{db: db}
+}
+
+func (r *menuRepo) GetActiveRows(ctx context.Context) ([]dto.MenuRowDTO, error) {
+
+	rows := []dto.MenuRowDTO{}
+
+	query := fmt.Sprintf(`
+		SELECT section.section_id,
+			section.section_name,
+			section.section_path,
+			section.section_icon,
+			section.section_order,
+			page.page_id,
+			page.page_name,
+			page.page_path,
+			page.page_order
+		FROM %s AS section
+
+	INNER JOIN %s AS page
+		ON page.section_no = section.section_no
+
+	WHERE section.status = ? AND page.status = ?
+		ORDER BY section.section_order ASC, section.section_no ASC, page.page_order ASC, page.page_no ASC`,
+		__SECTION_TBL__, __PAGE_TBL__)
+
+	if err := database.Reader(ctx, r.db).Raw(query, models.Active, models.Active).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
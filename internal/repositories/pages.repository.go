@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const __PAGE_TBL__ = "master.pages"
+
+type pageRepo struct {
+	baseRepo[dto.PageResponseDTO]
+}
+
+func NewPageRepository(db *gorm.DB) interfaces.PageRepository {
+	return &pageRepo{newBaseRepo[dto.PageResponseDTO](db, tableSpec{
+		table:    __PAGE_TBL__,
+		alias:    "page",
+		idColumn: "page_id",
+		columns: func(context.Context) string {
+			return `page.page_id,
+			section.section_id,
+			page.page_name,
+			page.page_path,
+			page.page_order,
+			page.status,
+			page.created_at,
+			page.created_by,
+			page.updated_at,
+			page.updated_by`
+		},
+		joins: func(context.Context) string {
+			return fmt.Sprintf(`INNER JOIN %s AS section
+		ON section.section_no = page.section_no`, __SECTION_TBL__)
+		},
+		notFound: interfaces.ErrPageNotFound,
+	})}
+}
+
+func (r *pageRepo) Create(ctx context.Context, sectionId uuid.UUID, data dto.PageRequestDTO) (uuid.UUID, error) {
+
+	tx := database.Conn(ctx, r.db)
+
+	sectionNo, err := sectionNoById(tx, sectionId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if data.Status == "" {
+		data.Status = models.Active
+	}
+
+	if data.PageOrder == 0 {
+		if data.PageOrder, err = nextPageOrder(tx, sectionNo); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	pageID := uuid.New()
+
+	insertFields := map[string]interface{}{
+		"page_id":    pageID,
+		"section_no": sectionNo,
+		"page_name":  data.PageName,
+		"page_path":  data.PagePath,
+		"page_order": data.PageOrder,
+		"status":     data.Status,
+	}
+	if err := r.Insert(ctx, insertFields); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to insert page: %w", err)
+	}
+
+	return pageID, nil
+}
+
+func (r *pageRepo) GetBySection(ctx context.Context, sectionId uuid.UUID) ([]dto.PageResponseDTO, error) {
+	return r.FindAll(ctx, "page.page_order ASC, page.page_name ASC", filterBy("section.section_id = ?", sectionId))
+}
+
+func (r *pageRepo) Update(ctx context.Context, id uuid.UUID, data dto.PageRequestDTO) error {
+
+	updateFields := map[string]interface{}{}
+
+	if data.PageName != "" {
+		updateFields["page_name"] = data.PageName
+	}
+	if data.PagePath != "" {
+		updateFields["page_path"] = data.PagePath
+	}
+	if data.PageOrder != 0 {
+		updateFields["page_order"] = data.PageOrder
+	}
+	if data.Status != "" {
+		updateFields["status"] = data.Status
+	}
+
+	return r.UpdateByID(ctx, id, updateFields)
+}
+
+func (r *pageRepo) Reorder(ctx context.Context, sectionId uuid.UUID, data dto.ReorderDTO) error {
+
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		sectionNo, err := sectionNoById(tx, sectionId)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range data.Items {
+			updateFields := map[string]interface{}{"page_order": item.Order}
+			stampUpdated(ctx, updateFields)
+
+			query, values := buildUpdateQuery(__PAGE_TBL__, updateFields, "page_id = ? AND section_no = ?", item.Id, sectionNo)
+
+			result := tx.Exec(query, values...)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("%w: %s", interfaces.ErrPageNotFound, item.Id)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *pageRepo) Move(ctx context.Context, id uuid.UUID, data dto.PageMoveDTO) error {
+
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		sectionNo, err := sectionNoById(tx, data.SectionId)
+		if err != nil {
+			return err
+		}
+
+		if data.PageOrder == 0 {
+			if data.PageOrder, err = nextPageOrder(tx, sectionNo); err != nil {
+				return err
+			}
+		}
+
+		updateFields := map[string]interface{}{
+			"section_no": sectionNo,
+			"page_order": data.PageOrder,
+		}
+		stampUpdated(ctx, updateFields)
+
+		query, values := buildUpdateQuery(__PAGE_TBL__, updateFields, "page_id = ?", id)
+
+		result := tx.Exec(query, values...)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return interfaces.ErrPageNotFound
+		}
+
+		return nil
+	})
+}
+
+func (r *pageRepo) ExistsByPath(ctx context.Context, path string, excludeId uuid.UUID) (bool, error) {
+	return r.Exists(ctx, "page_path = ? AND page_id <> ?", path, excludeId)
+}
+
+func sectionNoById(tx *gorm.DB, sectionId uuid.UUID) (uint8, error) {
+
+	var sectionNo uint8
+
+	query := fmt.Sprintf(`SELECT section_no FROM %s WHERE section_id = ? LIMIT 1`, __SECTION_TBL__)
+
+	result := tx.Raw(query, sectionId).Scan(&sectionNo)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return 0, interfaces.ErrSectionNotFound
+	}
+
+	return sectionNo, nil
+}
+
+func nextPageOrder(tx *gorm.DB, sectionNo uint8) (uint8, error) {
+
+	var order uint8
+
+	query := fmt.Sprintf(`SELECT COALESCE(MAX(page_order), 0) + 1 FROM %s WHERE section_no = ?`, __PAGE_TBL__)
+	if err := tx.Raw(query, sectionNo).Scan(&order).Error; err != nil {
+		return 0, err
+	}
+
+	return order, nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type permissionRepo struct {
+	db *gorm.DB
+}
+
+func NewPermissionRepository(db *gorm.DB) interfaces.PermissionRepository {
+	return &permissionRepo{db: db}
+}
+
+func (r *permissionRepo) FindRolePermissions(ctx context.Context, roleId uuid.UUID) (*dto.RolePermissionsDTO, error) {
+
+	var role dto.RolePermissionsDTO
+
+	query := fmt.Sprintf(`SELECT role_details, status FROM %s WHERE role_id = ? LIMIT 1`, database.Table(ctx, __ROLE_TBL__))
+
+	result := database.Conn(ctx, r.db).Raw(query, roleId).Scan(&role)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &role, nil
+}
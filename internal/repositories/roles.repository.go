@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// roleSortColumns whitelists the sort_by values accepted for roles.
+var roleSortColumns = map[string]string{
+	"role_name":  "role.role_name",
+	"status":     "role.status",
+	"created_at": "role.created_at",
+	"updated_at": "role.updated_at",
+}
+
+type roleRepo struct {
+	baseRepo[dto.RoleResponseDTO]
+}
+
+func NewRoleRepository(db *gorm.DB) interfaces.RoleRepository {
+	return &roleRepo{newBaseRepo[dto.RoleResponseDTO](db, tableSpec{
+		table:    __ROLE_TBL__,
+		tenant:   true,
+		alias:    "role",
+		idColumn: "role_id",
+		columns: func(ctx context.Context) string {
+			return fmt.Sprintf(`role.role_id,
+			role.role_name,
+			role.role_details,
+			role.status,
+			role.created_at,
+			role.created_by,
+			role.updated_at,
+			role.updated_by,
+			(SELECT COUNT(*) FROM %s AS profile
+				WHERE profile.role_no = role.role_no AND profile.status = '%s') AS active_users`,
+				database.Table(ctx, __PROFILE_TBL__), models.Active)
+		},
+		notFound:      interfaces.ErrRoleNotFound,
+		sortColumns:   roleSortColumns,
+		defaultSort:   "role_name",
+		searchColumns: []string{"role.role_name"},
+		softDelete:    true,
+	})}
+}
+
+func (r *roleRepo) Create(ctx context.Context, data dto.RoleRequestDTO) (uuid.UUID, error) {
+
+	if data.RoleDetails == nil {
+		data.RoleDetails = dto.PermissionSet{}
+	}
+	if data.Status == "" {
+		data.Status = models.Active
+	}
+
+	role := dto.RoleResponseDTO{
+		RoleId:      uuid.New(),
+		RoleName:    data.RoleName,
+		RoleDetails: data.RoleDetails,
+		Status:      data.Status,
+	}
+	if err := r.baseRepo.Create(ctx, &role); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to insert role: %w", err)
+	}
+
+	return role.RoleId, nil
+}
+
+func (r *roleRepo) FindByName(ctx context.Context, name string) (*dto.RoleResponseDTO, error) {
+	return r.FindWhere(ctx, filterBy("LOWER(role.role_name) = LOWER(?)", name))
+}
+
+func (r *roleRepo) Update(ctx context.Context, id uuid.UUID, data dto.RoleRequestDTO) error {
+
+	updateFields := map[string]interface{}{}
+
+	if data.RoleName != "" {
+		updateFields["role_name"] = data.RoleName
+	}
+	if data.RoleDetails != nil {
+		updateFields["role_details"] = data.RoleDetails
+	}
+
+	return r.UpdateByID(ctx, id, updateFields)
+}
+
+func (r *roleRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status models.StatusEnum) error {
+
+	tx := database.Conn(ctx, r.db)
+
+	var role models.Role
+	result := tx.Table(r.tableName(ctx)).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("role_id = ? AND status <> ?", id, models.Deleted).
+		Limit(1).
+		Find(&role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrRoleNotFound
+	}
+
+	if status != models.Active {
+		var activeUsers int64
+		countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE role_no = ? AND status = ?`, database.Table(ctx, __PROFILE_TBL__))
+		if err := tx.Raw(countQuery, role.RoleNo, models.Active).Scan(&activeUsers).Error; err != nil {
+			return err
+		}
+		if activeUsers > 0 {
+			return interfaces.ErrRoleInUse
+		}
+	}
+
+	if status == models.Deleted {
+		return r.SoftDelete(ctx, id)
+	}
+
+	return r.UpdateByID(ctx, id, map[string]interface{}{"status": status})
+}
+
+func (r *roleRepo) ExistsByName(ctx context.Context, name string, excludeId uuid.UUID) (bool, error) {
+	return r.Exists(ctx, "LOWER(role_name) = LOWER(?) AND role_id <> ? AND status <> ?", name, excludeId, models.Deleted)
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const __SECTION_TBL__ = "master.sections"
+
+// sectionSortColumns whitelists the sort_by values accepted for sections.
+var sectionSortColumns = map[string]string{
+	"section_order": "section.section_order",
+	"section_name":  "section.section_name",
+	"status":        "section.status",
+	"created_at":    "section.created_at",
+}
+
+type sectionRepo struct {
+	baseRepo[dto.SectionResponseDTO]
+}
+
+func NewSectionRepository(db *gorm.DB) interfaces.SectionRepository {
+	return &sectionRepo{newBaseRepo[dto.SectionResponseDTO](db, tableSpec{
+		table:    __SECTION_TBL__,
+		alias:    "section",
+		idColumn: "section_id",
+		columns: func(context.Context) string {
+			return `section.section_id,
+			section.section_name,
+			section.section_path,
+			section.section_icon,
+			section.section_order,
+			section.status,
+			section.created_at,
+			section.created_by,
+			section.updated_at,
+			section.updated_by`
+		},
+		notFound:      interfaces.ErrSectionNotFound,
+		sortColumns:   sectionSortColumns,
+		defaultSort:   "section_order",
+		searchColumns: []string{"section.section_name", "section.section_path"},
+	})}
+}
+
+func (r *sectionRepo) Create(ctx context.Context, data dto.SectionRequestDTO) (uuid.UUID, error) {
+
+	if data.Status == "" {
+		data.Status = models.Active
+	}
+
+	if data.SectionOrder == 0 {
+		query := fmt.Sprintf(`SELECT COALESCE(MAX(section_order), 0) + 1 FROM %s`, __SECTION_TBL__)
+		if err := database.Conn(ctx, r.db).Raw(query).Scan(&data.SectionOrder).Error; err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	section := dto.SectionResponseDTO{
+		SectionId:    uuid.New(),
+		SectionName:  data.SectionName,
+		SectionPath:  data.SectionPath,
+		SectionIcon:  data.SectionIcon,
+		SectionOrder: data.SectionOrder,
+		Status:       data.Status,
+	}
+	if err := r.baseRepo.Create(ctx, &section); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to insert section: %w", err)
+	}
+
+	return section.SectionId, nil
+}
+
+func (r *sectionRepo) Update(ctx context.Context, id uuid.UUID, data dto.SectionRequestDTO) error {
+
+	updateFields := map[string]interface{}{}
+
+	if data.SectionName != "" {
+		updateFields["section_name"] = data.SectionName
+	}
+	if data.SectionPath != "" {
+		updateFields["section_path"] = data.SectionPath
+	}
+	if data.SectionIcon != "" {
+		updateFields["section_icon"] = data.SectionIcon
+	}
+	if data.SectionOrder != 0 {
+		updateFields["section_order"] = data.SectionOrder
+	}
+	if data.Status != "" {
+		updateFields["status"] = data.Status
+	}
+
+	return r.UpdateByID(ctx, id, updateFields)
+}
+
+func (r *sectionRepo) Reorder(ctx context.Context, data dto.ReorderDTO) error {
+
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		for _, item := range data.Items {
+			updateFields := map[string]interface{}{"section_order": item.Order}
+			stampUpdated(ctx, updateFields)
+
+			query, values := buildUpdateQuery(__SECTION_TBL__, updateFields, "section_id = ?", item.Id)
+
+			result := tx.Exec(query, values...)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("%w: %s", interfaces.ErrSectionNotFound, item.Id)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *sectionRepo) ExistsByPath(ctx context.Context, path string, excludeId uuid.UUID) (bool, error) {
+	return r.Exists(ctx, "section_path = ? AND section_id <> ?", path, excludeId)
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"gorm.io/gorm"
+)
+
+// pingTimeout keeps the stats endpoint responsive when the database hangs.
+const pingTimeout = 2 * time.Second
+
+type systemRepo struct {
+	db *gorm.DB
+}
+
+func NewSystemRepository(db *gorm.DB) interfaces.SystemRepository {
+	return &systemRepo{db: db}
+}
+
+func (r *systemRepo) DatabaseStats(ctx context.Context) (*dto.DatabaseStatsDTO, error) {
+
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	started := time.Now()
+	pingErr := sqlDB.PingContext(ctx)
+
+	stats := sqlDB.Stats()
+
+	result := &dto.DatabaseStatsDTO{
+		Healthy:            pingErr == nil,
+		PingMs:             time.Since(started).Milliseconds(),
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+	if pingErr != nil {
+		// The driver error names hosts and ports; it is only logged.
+		slog.WarnContext(ctx, "database ping failed", slog.Any("error", pingErr))
+		result.Error = "database did not answer the ping"
+	}
+
+	result.Replicas = []dto.ReplicaStatusDTO{}
+	for _, replica := range database.ReplicaStatuses() {
+		result.Replicas = append(result.Replicas, dto.ReplicaStatusDTO{Name: replica.Name, Healthy: replica.Healthy})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"gorm.io/gorm"
+)
+
+// The tenant registry is shared, so it is always read from master.
+const __TENANT_TBL__ = "master.tenants"
+
+type tenantRepo struct {
+	db *gorm.DB
+}
+
+func NewTenantRepository(db *gorm.DB) interfaces.TenantRepository {
+	return &tenantRepo{db: db}
+}
+
+func (r *tenantRepo) Create(ctx context.Context, data dto.TenantDTO) error {
+
+	currentTime := time.Now().UTC()
+
+	insertFields := map[string]interface{}{
+		"tenant_id":   data.TenantId,
+		"slug":        data.Slug,
+		"tenant_name": data.TenantName,
+		"schema_name": data.SchemaName,
+		"status":      data.Status,
+		"created_at":  currentTime,
+		"updated_at":  currentTime,
+	}
+
+	cols, vals, args := buildSQLParts(insertFields)
+	query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`, __TENANT_TBL__, cols, vals)
+
+	if err := database.Conn(ctx, r.db).Exec(query, args...).Error; err != nil {
+		return fmt.Errorf("failed to insert tenant: %w", err)
+	}
+
+	return nil
+}
+
+func (r *tenantRepo) GetAll(ctx context.Context) ([]dto.TenantDTO, error) {
+
+	var tenants []dto.TenantDTO
+
+	query := fmt.Sprintf(`
+		SELECT tenant_id, slug, tenant_name, schema_name, status, created_at
+		FROM %s
+		WHERE status <> ?
+		ORDER BY slug`, __TENANT_TBL__)
+
+	if err := database.Reader(ctx, r.db).Raw(query, models.Deleted).Scan(&tenants).Error; err != nil {
+		return nil, err
+	}
+
+	return tenants, nil
+}
+
+func (r *tenantRepo) FindBySlug(ctx context.Context, slug string) (*dto.TenantDTO, error) {
+
+	var tenant dto.TenantDTO
+
+	query := fmt.Sprintf(`
+		SELECT tenant_id, slug, tenant_name, schema_name, status, created_at
+		FROM %s
+		WHERE slug = ? AND status <> ?
+		LIMIT 1`, __TENANT_TBL__)
+
+	result := database.Reader(ctx, r.db).Raw(query, slug, models.Deleted).Scan(&tenant)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &tenant, nil
+}
+
+func (r *tenantRepo) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
+
+	var count int64
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE slug = ?`, __TENANT_TBL__)
+	if err := database.Reader(ctx, r.db).Raw(query, slug).Scan(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -1,301 +1,738 @@
 package repository
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"log"
+	"strings"
 	"time"
 
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
 	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
 	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
 	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
-const __ROLE_TBL__ = "master.roles"
-const __PROFILE_TBL__ = "master.users"
-const __CREDENTIAL_TBL__ = "master.user_credentials"
+// Tenant tables are named without a schema; database.Table qualifies them
+// with the schema of the tenant the request is scoped to.
+const __ROLE_TBL__ = "roles"
+const __PROFILE_TBL__ = "users"
+const __CREDENTIAL_TBL__ = "user_credentials"
 
 type userRepo struct {
-	db *gorm.DB
+	baseRepo[dto.ResponseDTO]
 }
 
 func NewUserRepository(db *gorm.DB) interfaces.UserRepository {
-	return &userRepo{db: db}
+	return &userRepo{newBaseRepo[dto.ResponseDTO](db, tableSpec{
+		table:    __PROFILE_TBL__,
+		tenant:   true,
+		alias:    "profile",
+		idColumn: "profile_id",
+		columns: func(context.Context) string {
+			return `profile.profile_id,
+			profile.user_full_name,
+			profile.email_id,
+			profile.gender,
+			profile.dob,
+			profile.mobile_no,
+			profile.address,
+			profile.status,
+			profile.created_at,
+			profile.created_by,
+			profile.updated_at,
+			profile.updated_by,
+			role.role_id`
+		},
+		joins: func(ctx context.Context) string {
+			return fmt.Sprintf(`INNER JOIN %s AS role
+		ON role.role_no = profile.role_no`, database.Table(ctx, __ROLE_TBL__))
+		},
+		notFound:      interfaces.ErrUserNotFound,
+		sortColumns:   dto.UserSortFields,
+		defaultSort:   "user_full_name",
+		searchColumns: []string{"profile.user_full_name", "profile.email_id"},
+		softDelete:    true,
+	})}
 }
 
-func (r *userRepo) Create(data dto.RequestDTO) (uuid.UUID, error) {
-	tx := r.db.Begin()
-	if tx.Error != nil {
-		return uuid.Nil, tx.Error
+func (r *userRepo) Create(ctx context.Context, data dto.RequestDTO) (uuid.UUID, error) {
+
+	hashedPassword, err := utils.HashPassword(data.Password)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("password hashing failed: %w", err)
 	}
 
-	// Rollback on panic
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-			log.Printf("panic during Create transaction: %v", r)
+	profileID := uuid.New()
+
+	err = database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		insertFields := make(map[string]interface{})
+
+		if data.UserFullName != "" {
+			insertFields["user_full_name"] = data.UserFullName
+		}
+		if data.RoleId != uuid.Nil {
+			roleNo, err := roleNoById(ctx, tx, data.RoleId)
+			if err != nil {
+				return err
+			}
+			insertFields["role_no"] = roleNo
+		}
+		if data.EmailId != "" {
+			insertFields["email_id"] = data.EmailId
+		}
+		if data.Gender != "" {
+			insertFields["gender"] = data.Gender
+		}
+		if data.Dob != nil {
+			insertFields["dob"] = data.Dob
+		}
+		if data.MobileNo != "" {
+			insertFields["mobile_no"] = data.MobileNo
+		}
+		if len(data.Address) != 0 {
+			insertFields["address"] = data.Address
+		}
+		if data.Status != "" {
+			insertFields["status"] = data.Status
 		}
-	}()
 
-	insertFields := make(map[string]interface{})
+		insertFields["profile_id"] = profileID
+		stampCreated(ctx, insertFields)
 
-	if data.UserFullName != "" {
-		insertFields["user_full_name"] = data.UserFullName
-	}
-	if data.RoleId != uuid.Nil {
-		var roleNo int
-		query := fmt.Sprintf(`SELECT role_no FROM %s WHERE role_id = ?`, __ROLE_TBL__)
-		if err := tx.Raw(query, data.RoleId).Scan(&roleNo).Error; err != nil {
-			tx.Rollback()
-			return uuid.Nil, err
+		userCols, userVals, userArgs := buildSQLParts(insertFields)
+
+		rawQuery := fmt.Sprintf(
+			`INSERT INTO %s (%s) VALUES (%s) RETURNING profile_no`,
+			database.Table(ctx, __PROFILE_TBL__), userCols, userVals,
+		)
+
+		var profileNo int
+		if err := tx.Raw(rawQuery, userArgs...).Scan(&profileNo).Error; err != nil {
+			return fmt.Errorf("failed to insert profile: %w", err)
+		}
+
+		credentialID := uuid.New()
+		credFields := map[string]interface{}{
+			"credential_id": credentialID,
+			"profile_no":    profileNo,
+			"username":      data.Username,
+			"password":      hashedPassword,
+		}
+		stampCreated(ctx, credFields)
+		if data.Status != "" {
+			credFields["status"] = data.Status
+		}
+
+		credCols, credVals, credArgs := buildSQLParts(credFields)
+		credQuery := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`, database.Table(ctx, __CREDENTIAL_TBL__), credCols, credVals)
+
+		if err := tx.Exec(credQuery, credArgs...).Error; err != nil {
+			return fmt.Errorf("failed to insert credentials: %w", err)
 		}
-		insertFields["role_no"] = roleNo
+
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
 	}
-	if data.EmailId != "" {
-		insertFields["email_id"] = data.EmailId
+
+	return profileID, nil
+}
+
+// List pages through the users matching the search, status and the filters
+// of the user listing.
+func (r *userRepo) List(ctx context.Context, params dto.PaginationParams) (dto.PageResult[dto.ResponseDTO], error) {
+	return r.list(ctx, params, userFilters(params)...)
+}
+
+// userFilters turns the filters of the user listing into conditions.
+func userFilters(params dto.PaginationParams) []filter {
+
+	var filters []filter
+
+	if params.RoleId != nil {
+		filters = append(filters, filterBy("role.role_id = ?", *params.RoleId))
 	}
-	if data.Gender != "" {
-		insertFields["gender"] = data.Gender
+	if params.Gender != "" {
+		filters = append(filters, filterBy("LOWER(profile.gender) = LOWER(?)", params.Gender))
 	}
-	if data.Dob != nil {
-		insertFields["dob"] = data.Dob
+	if params.MobileNo != "" {
+		filters = append(filters, filterBy("profile.mobile_no LIKE ?", "%"+params.MobileNo+"%"))
 	}
-	if data.MobileNo != "" {
-		insertFields["mobile_no"] = data.MobileNo
+	if params.CreatedFrom != nil {
+		filters = append(filters, filterBy("profile.created_at >= ?", *params.CreatedFrom))
 	}
-	if len(data.Address) != 0 {
-		insertFields["address"] = data.Address
+	if params.CreatedTo != nil {
+		// Inclusive of the whole day.
+		filters = append(filters, filterBy("profile.created_at < ?", params.CreatedTo.AddDate(0, 0, 1)))
+	}
+	if params.DobFrom != nil {
+		filters = append(filters, filterBy("profile.dob >= ?", *params.DobFrom))
+	}
+	if params.DobTo != nil {
+		filters = append(filters, filterBy("profile.dob <= ?", *params.DobTo))
 	}
 
-	currentTime := time.Now().UTC()
-	profileID := uuid.New()
-
-	insertFields["profile_id"] = profileID
-	insertFields["created_at"] = currentTime
+	return filters
+}
 
-	userCols, userVals, userArgs := buildSQLParts(insertFields)
+func (r *userRepo) Update(ctx context.Context, id uuid.UUID, data dto.RequestDTO) error {
 
-	rawQuery := fmt.Sprintf(
-		`INSERT INTO %s (%s) VALUES (%s) RETURNING profile_no`,
-		__PROFILE_TBL__, userCols, userVals,
-	)
+	credFields := map[string]interface{}{}
 
-	var profileNo int
-	if err := tx.Raw(rawQuery, userArgs...).Scan(&profileNo).Error; err != nil {
-		tx.Rollback()
-		return uuid.Nil, fmt.Errorf("failed to insert profile: %w", err)
+	if data.Username != "" {
+		credFields["username"] = data.Username
+	}
+	if data.Password != "" {
+		hashedPassword, err := utils.HashPassword(data.Password)
+		if err != nil {
+			return fmt.Errorf("password hashing failed: %w", err)
+		}
+		credFields["password"] = hashedPassword
 	}
 
-	hashedPassword, err := utils.HashPassword(data.Password)
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		updateFields := map[string]interface{}{}
+
+		if data.UserFullName != "" {
+			updateFields["user_full_name"] = data.UserFullName
+		}
+		if data.RoleId != uuid.Nil {
+			roleNo, err := roleNoById(ctx, tx, data.RoleId)
+			if err != nil {
+				return err
+			}
+			updateFields["role_no"] = roleNo
+		}
+		if data.EmailId != "" {
+			updateFields["email_id"] = data.EmailId
+		}
+		if data.Gender != "" {
+			updateFields["gender"] = data.Gender
+		}
+		if data.Dob != nil {
+			updateFields["dob"] = data.Dob
+		}
+		if data.MobileNo != "" {
+			updateFields["mobile_no"] = data.MobileNo
+		}
+		if len(data.Address) != 0 {
+			updateFields["address"] = data.Address
+		}
+		if data.Status != "" {
+			// Login checks the credential, so both change together.
+			updateFields["status"] = data.Status
+			credFields["status"] = data.Status
+		}
+
+		if len(updateFields) == 0 && len(credFields) == 0 {
+			return interfaces.ErrNoFieldsToUpdate
+		}
+
+		stampUpdated(ctx, updateFields)
+
+		query, values := buildUpdateQuery(database.Table(ctx, __PROFILE_TBL__), updateFields, "profile_id = ? AND status <> ?", id, models.Deleted)
+
+		var profileNo uint32
+		result := tx.Raw(query+" RETURNING profile_no", values...).Scan(&profileNo)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return interfaces.ErrUserNotFound
+		}
+
+		if len(credFields) == 0 {
+			return nil
+		}
+
+		return updateCredential(ctx, tx, profileNo, credFields)
+	})
+}
+
+func (r *userRepo) SoftDelete(ctx context.Context, id uuid.UUID) error {
+
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		profileNo, err := r.changeStatus(ctx, tx, id, models.Deleted, "status <> ?", models.Deleted)
+		if err != nil {
+			return err
+		}
+
+		credFields := map[string]interface{}{
+			"status":         models.Deleted,
+			"restore_status": gorm.Expr("status"),
+		}
+		stampUpdated(ctx, credFields)
+
+		credQuery, credArgs := buildUpdateQuery(database.Table(ctx, __CREDENTIAL_TBL__), credFields, "profile_no = ? AND status <> ?", profileNo, models.Deleted)
+		if err := tx.Exec(credQuery, credArgs...).Error; err != nil {
+			return fmt.Errorf("failed to disable credentials: %w", err)
+		}
+
+		return revokeRefreshTokens(ctx, tx, profileNo)
+	})
+}
+
+func (r *userRepo) Restore(ctx context.Context, id uuid.UUID) error {
+
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		profileNo, err := r.changeStatus(ctx, tx, id, models.Active, "status = ?", models.Deleted)
+		if err != nil {
+			return err
+		}
+
+		// Credentials deleted before their previous status was kept come
+		// back inactive and need an explicit activation.
+		credFields := map[string]interface{}{
+			"status":         gorm.Expr("COALESCE(restore_status, ?)", models.Inactive),
+			"restore_status": nil,
+		}
+		stampUpdated(ctx, credFields)
+
+		credQuery, credArgs := buildUpdateQuery(database.Table(ctx, __CREDENTIAL_TBL__), credFields, "profile_no = ? AND status = ?", profileNo, models.Deleted)
+
+		var restored models.StatusEnum
+		result := tx.Raw(credQuery+" RETURNING status", credArgs...).Scan(&restored)
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore credentials: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 || restored == models.Active {
+			return nil
+		}
+
+		// The profile follows its credential, as on updates.
+		_, err = r.changeStatus(ctx, tx, id, restored, "status = ?", models.Active)
+		return err
+	})
+}
+
+func (r *userRepo) ResetPassword(ctx context.Context, username, password string) error {
+
+	hashedPassword, err := utils.HashPassword(password)
 	if err != nil {
-		tx.Rollback()
-		return uuid.Nil, fmt.Errorf("password hashing failed: %w", err)
+		return fmt.Errorf("password hashing failed: %w", err)
 	}
 
-	credentialID := uuid.New()
-	credFields := map[string]interface{}{
-		"credential_id": credentialID,
-		"profile_no":    profileNo,
-		"username":      data.Username,
-		"password":      hashedPassword,
-		"created_at":    currentTime,
+	return database.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+
+		updateFields := map[string]interface{}{"password": hashedPassword}
+		stampUpdated(ctx, updateFields)
+
+		query, values := buildUpdateQuery(database.Table(ctx, __CREDENTIAL_TBL__), updateFields, "username = ? AND status <> ?", username, models.Deleted)
+
+		var profileNo uint32
+		result := tx.Raw(query+" RETURNING profile_no", values...).Scan(&profileNo)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return interfaces.ErrUserNotFound
+		}
+
+		return revokeRefreshTokens(ctx, tx, profileNo)
+	})
+}
+
+func (r *userRepo) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+
+	// Credentials and refresh tokens are removed by ON DELETE CASCADE.
+	query := fmt.Sprintf(`DELETE FROM %s WHERE status = ? AND updated_at < ?`, database.Table(ctx, __PROFILE_TBL__))
+
+	result := database.Conn(ctx, r.db).Exec(query, models.Deleted, before)
+	if result.Error != nil {
+		return 0, result.Error
 	}
 
-	credCols, credVals, credArgs := buildSQLParts(credFields)
-	credQuery := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`, __CREDENTIAL_TBL__, credCols, credVals)
+	return result.RowsAffected, nil
+}
 
-	if err := tx.Exec(credQuery, credArgs...).Error; err != nil {
-		tx.Rollback()
-		return uuid.Nil, fmt.Errorf("failed to insert credentials: %w", err)
+// changeStatus moves the profile matching id and condition to status and
+// returns its profile_no, or ErrUserNotFound when nothing matched.
+func (r *userRepo) changeStatus(ctx context.Context, tx *gorm.DB, id uuid.UUID, status models.StatusEnum, condition string, conditionArgs ...interface{}) (uint32, error) {
+
+	updateFields := map[string]interface{}{"status": status}
+	stampUpdated(ctx, updateFields)
+
+	query, values := buildUpdateQuery(database.Table(ctx, __PROFILE_TBL__), updateFields, "profile_id = ? AND "+condition, append([]interface{}{id}, conditionArgs...)...)
+
+	var profileNo uint32
+	result := tx.Raw(query+" RETURNING profile_no", values...).Scan(&profileNo)
+	if result.Error != nil {
+		return 0, result.Error
 	}
 
-	if err := tx.Commit().Error; err != nil {
-		return uuid.Nil, fmt.Errorf("transaction commit failed: %w", err)
+	if result.RowsAffected == 0 {
+		return 0, interfaces.ErrUserNotFound
 	}
 
-	return profileID, nil
+	return profileNo, nil
 }
 
-func (r *userRepo) FindOne(id uuid.UUID) (*dto.ResponseDTO, error) {
+// updateCredential sets fields on the credential of profileNo unless it is
+// deleted. A new password or a status other than Active also revokes the
+// user's refresh tokens.
+func updateCredential(ctx context.Context, tx *gorm.DB, profileNo uint32, fields map[string]interface{}) error {
 
-	var user dto.ResponseDTO
+	_, newPassword := fields["password"]
+	status, statusChanged := fields["status"]
 
-	query := fmt.Sprintf(`
-		SELECT profile.profile_no, 
-			profile.profile_id, 
-			profile.user_full_name,
-			profile.email_id,
-			profile.gender,
-			profile.dob,
-			profile.mobile_no,
-			profile.address,
-			profile.status,
-			profile.created_at,
-			profile.created_by,
-			profile.updated_at,
-			profile.updated_by,
-			role.role_id,
-			role.role_name
-		FROM %s AS profile
+	stampUpdated(ctx, fields)
 
-	INNER JOIN %s AS role 
-		ON role.role_no = profile.role_no
-	
-	WHERE profile.profile_id = ?
-		LIMIT 1`, __PROFILE_TBL__, __ROLE_TBL__)
+	credQuery, credArgs := buildUpdateQuery(database.Table(ctx, __CREDENTIAL_TBL__), fields, "profile_no = ? AND status <> ?", profileNo, models.Deleted)
+	if err := tx.Exec(credQuery, credArgs...).Error; err != nil {
+		return fmt.Errorf("failed to update credentials: %w", err)
+	}
 
-	err := r.db.Raw(query, id).Scan(&user).Error
-	if err != nil {
-		return nil, err
+	if !newPassword && (!statusChanged || status == models.Active) {
+		return nil
+	}
+
+	return revokeRefreshTokens(ctx, tx, profileNo)
+}
+
+// revokeRefreshTokens revokes every live refresh token of profileNo.
+func revokeRefreshTokens(ctx context.Context, tx *gorm.DB, profileNo uint32) error {
+
+	revokeQuery := fmt.Sprintf(`UPDATE %s SET revoked_at = ? WHERE profile_no = ? AND revoked_at IS NULL`, database.Table(ctx, __REFRESH_TOKEN_TBL__))
+	if err := tx.Exec(revokeQuery, time.Now().UTC(), profileNo).Error; err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
 	}
 
-	return &user, nil
+	return nil
 }
 
-func (r *userRepo) GetAll(params dto.PaginationParams) ([]dto.ResponseDTO, int64, error) {
+// keysetKind tells how a sort key is rendered into and parsed from a cursor.
+type keysetKind int
+
+const (
+	keysetText keysetKind = iota
+	keysetDate
+	keysetTimestamp
+)
+
+type keysetColumn struct {
+	// expr is the dto.UserSortFields column keyset pages are ordered and
+	// compared on. NULLs sort before every value, as in the (column NULLS
+	// FIRST, profile_no) indexes of the users table.
+	expr string
+	kind keysetKind
+}
 
-	var users []dto.ResponseDTO
-	var total int64
+var userKeysetColumns = map[string]keysetColumn{
+	"user_full_name": {"profile.user_full_name", keysetText},
+	"email_id":       {"profile.email_id", keysetText},
+	"gender":         {"profile.gender", keysetText},
+	"dob":            {"profile.dob", keysetDate},
+	"mobile_no":      {"profile.mobile_no", keysetText},
+	"status":         {"profile.status", keysetText},
+	"created_at":     {"profile.created_at", keysetTimestamp},
+	"updated_at":     {"profile.updated_at", keysetTimestamp},
+	"role_name":      {"role.role_name", keysetText},
+}
 
-	where := "WHERE 1=1"
-	args := []interface{}{}
+// keysetTimestampLayout renders timestamps in UTC at full precision, so a
+// cursor compares equal to the row it was taken from.
+const keysetTimestampLayout = `'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'`
 
-	if params.Search != "" {
-		where += " AND (profile.user_full_name ILIKE ? OR profile.email_id ILIKE ?)"
-		search := "%" + params.Search + "%"
-		args = append(args, search, search)
+func (c keysetColumn) selectExpr() string {
+	switch c.kind {
+	case keysetDate:
+		return fmt.Sprintf("to_char(%s, 'YYYY-MM-DD')", c.expr)
+	case keysetTimestamp:
+		return fmt.Sprintf("to_char(%s AT TIME ZONE 'UTC', %s)", c.expr, keysetTimestampLayout)
+	default:
+		return c.expr
 	}
+}
 
-	if params.Status != "" {
-		where += " AND profile.status = ?"
-		args = append(args, params.Status)
+func (c keysetColumn) parse(value string) (interface{}, error) {
+	switch c.kind {
+	case keysetDate:
+		return time.Parse(time.DateOnly, value)
+	case keysetTimestamp:
+		return time.Parse(time.RFC3339Nano, value)
+	default:
+		return value, nil
 	}
+}
 
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s AS profile %s", __PROFILE_TBL__, where)
-	if err := r.db.Raw(countQuery, args...).Scan(&total).Error; err != nil {
-		return nil, 0, err
+// order sorts on the column, keeping NULLs before every value.
+func (c keysetColumn) order(desc bool) string {
+	if desc {
+		return c.expr + " DESC NULLS LAST"
 	}
+	return c.expr + " ASC NULLS FIRST"
+}
 
-	if params.Order != "ASC" && params.Order != "DESC" {
-		params.Order = "ASC"
+// equal matches the rows whose key is value, nil standing for NULL.
+func (c keysetColumn) equal(value interface{}) (string, []interface{}) {
+	if value == nil {
+		return c.expr + " IS NULL", nil
 	}
-	if params.SortBy == "" {
-		params.SortBy = "user_full_name"
+	return c.expr + " = ?", []interface{}{value}
+}
+
+// beyond matches the rows whose key follows value in the given direction.
+// ok is false when no key can, as nothing sorts before NULL.
+func (c keysetColumn) beyond(value interface{}, desc bool) (condition string, args []interface{}, ok bool) {
+	switch {
+	case value == nil && desc:
+		return "", nil, false
+	case value == nil:
+		return c.expr + " IS NOT NULL", nil, true
+	case desc:
+		return "(" + c.expr + " < ? OR " + c.expr + " IS NULL)", []interface{}{value}, true
+	default:
+		return c.expr + " > ?", []interface{}{value}, true
 	}
+}
 
-	offset := (params.Page - 1) * params.Size
+// userKeysetRow is a listed user together with the position it is paged by.
+type userKeysetRow struct {
+	dto.ResponseDTO
+	ProfileNo int64
+	SortKey   string
+}
 
-	query := fmt.Sprintf(`
-		SELECT profile.profile_no, 
-			profile.profile_id,
-			profile.user_full_name,
-			profile.email_id,
-			profile.gender,
-			profile.dob,
-			profile.mobile_no,
-			profile.address,
-			profile.status,
-			profile.created_at,
-			profile.updated_at,
-			role.role_id,
-			role.role_name
-		FROM %s AS profile
-		INNER JOIN %s AS role ON role.role_no = profile.role_no
-		%s
-		ORDER BY %s %s
-		LIMIT ? OFFSET ?`,
-		__PROFILE_TBL__, __ROLE_TBL__, where, params.SortBy, params.Order)
+func (r *userRepo) GetPage(ctx context.Context, params dto.PaginationParams) ([]dto.ResponseDTO, *dto.CursorPage, error) {
 
-	args = append(args, params.Size, offset)
+	sort := params.Sort
+	if len(sort) == 0 {
+		sort = []dto.SortField{{Field: "user_full_name"}}
+	}
 
-	if err := r.db.Raw(query, args...).Scan(&users).Error; err != nil {
-		return nil, 0, err
+	columns := make([]keysetColumn, 0, len(sort))
+	for _, field := range sort {
+		column, ok := userKeysetColumns[field.Field]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown sort field %q", field.Field)
+		}
+		columns = append(columns, column)
 	}
 
-	return users, total, nil
-}
+	filters := r.listFilters(params, userFilters(params)...)
+	page := &dto.CursorPage{}
 
-func (r *userRepo) Update(id uuid.UUID, data dto.RequestDTO) error {
-	tx := r.db.Begin()
-	if tx.Error != nil {
-		return tx.Error
+	switch params.Count {
+	case dto.CountExact:
+		total, err := r.count(ctx, filters)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.TotalRecords = &total
+	case dto.CountEstimate:
+		total, err := r.estimateUsers(ctx, filters)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.TotalRecords = &total
+		page.TotalEstimated = true
 	}
 
-	updateFields := map[string]interface{}{}
+	// Rows before the cursor are fetched in reverse order and flipped back.
+	backward := params.Cursor != nil && params.Cursor.Before
 
-	if data.UserFullName != "" {
-		updateFields["user_full_name"] = data.UserFullName
-	}
-	if data.RoleId != uuid.Nil {
-		var roleNo int
-		query := fmt.Sprintf(`SELECT role_no FROM %s WHERE role_id = ?`, __ROLE_TBL__)
-		if err := tx.Raw(query, data.RoleId).Scan(&roleNo).Error; err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to fetch role_no: %v", err)
+	if params.Cursor != nil {
+		condition, conditionArgs, err := keysetCondition(columns, sort, params.Cursor, backward)
+		if err != nil {
+			return nil, nil, err
 		}
-		updateFields["role_no"] = roleNo
+		filters = append(filters, filterBy(condition, conditionArgs...))
 	}
-	if data.EmailId != "" {
-		updateFields["email_id"] = data.EmailId
+
+	orderBy := make([]string, 0, len(columns)+1)
+	keys := make([]string, 0, len(columns))
+	for i, column := range columns {
+		orderBy = append(orderBy, column.order(sort[i].Desc != backward))
+		keys = append(keys, column.selectExpr())
 	}
-	if data.Gender != "" {
-		updateFields["gender"] = data.Gender
+	orderBy = append(orderBy, "profile.profile_no "+keysetDirection(keysetTieDesc(sort, backward)))
+
+	where, args := joinFilters(filters)
+
+	query := fmt.Sprintf(`
+		SELECT profile.profile_no,
+			%s,
+			json_build_array(%s)::text AS sort_key
+		FROM %s
+		%s
+		ORDER BY %s
+		LIMIT ?`,
+		r.spec.columns(ctx), strings.Join(keys, ", "), r.from(ctx), where, strings.Join(orderBy, ", "))
+
+	var rows []userKeysetRow
+
+	// One extra row tells whether another page follows.
+	if err := database.Reader(ctx, r.db).Raw(query, append(args, params.Size+1)...).Scan(&rows).Error; err != nil {
+		return nil, nil, err
 	}
-	if data.Dob != nil {
-		updateFields["dob"] = data.Dob
+
+	more := len(rows) > params.Size
+	if more {
+		rows = rows[:params.Size]
 	}
-	if data.MobileNo != "" {
-		updateFields["mobile_no"] = data.MobileNo
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
 	}
-	if len(data.Address) != 0 {
-		updateFields["address"] = data.Address
+
+	users := make([]dto.ResponseDTO, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, row.ResponseDTO)
 	}
-	if data.Status != "" {
-		updateFields["status"] = data.Status
+
+	if len(rows) > 0 {
+		sortSpec := dto.SortSpec(sort)
+		first, last := rows[0], rows[len(rows)-1]
+
+		if (backward && more) || (!backward && params.Cursor != nil) {
+			page.PrevCursor = utils.EncodeCursor(dto.Cursor{Sort: sortSpec, Values: []byte(first.SortKey), Id: first.ProfileNo, Before: true})
+		}
+		if (!backward && more) || backward {
+			page.NextCursor = utils.EncodeCursor(dto.Cursor{Sort: sortSpec, Values: []byte(last.SortKey), Id: last.ProfileNo})
+		}
 	}
 
-	if len(updateFields) == 0 {
-		tx.Rollback()
-		return fmt.Errorf("no fields provided for update")
+	return users, page, nil
+}
+
+// keysetCondition matches the rows after the cursor in the listing's order,
+// or before it when backward:
+//
+//	k1 > v1 OR (k1 = v1 AND k2 > v2) OR ... OR (k1 = v1 AND ... AND profile_no > id)
+//
+// with > turned into < for descending keys and every comparison spelled out
+// for NULL keys by keysetColumn.equal and beyond. profile_no ties follow the
+// direction of the last key, so a single-key listing reads its index in one
+// direction.
+func keysetCondition(columns []keysetColumn, sort []dto.SortField, cursor *dto.Cursor, backward bool) (string, []interface{}, error) {
+
+	var raw []*string
+	if err := json.Unmarshal(cursor.Values, &raw); err != nil || len(raw) != len(columns) {
+		return "", nil, interfaces.ErrInvalidCursor
+	}
+
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		if raw[i] == nil {
+			continue
+		}
+		value, err := column.parse(*raw[i])
+		if err != nil {
+			return "", nil, interfaces.ErrInvalidCursor
+		}
+		values[i] = value
 	}
 
-	updateFields["updated_at"] = time.Now().UTC()
-	updateFields["updated_by"] = data.UpdatedBy
+	var terms []string
+	var args []interface{}
+
+	for i := 0; i <= len(columns); i++ {
+		var parts []string
+		var partArgs []interface{}
+
+		for j := 0; j < i; j++ {
+			part, partArg := columns[j].equal(values[j])
+			parts = append(parts, part)
+			partArgs = append(partArgs, partArg...)
+		}
 
-	query := fmt.Sprintf("UPDATE %s SET ", __PROFILE_TBL__)
-	values := []interface{}{}
-	i := 0
-	for field, value := range updateFields {
-		if i > 0 {
-			query += ", "
+		if i < len(columns) {
+			part, partArg, ok := columns[i].beyond(values[i], sort[i].Desc != backward)
+			if !ok {
+				continue
+			}
+			parts = append(parts, part)
+			partArgs = append(partArgs, partArg...)
+		} else {
+			parts = append(parts, "profile.profile_no "+keysetOperator(keysetTieDesc(sort, backward))+" ?")
+			partArgs = append(partArgs, cursor.Id)
 		}
-		query += field + " = ?"
-		values = append(values, value)
-		i++
+
+		terms = append(terms, "("+strings.Join(parts, " AND ")+")")
+		args = append(args, partArgs...)
 	}
-	query += " WHERE profile_id = ?"
-	values = append(values, id)
 
-	if err := tx.Exec(query, values...).Error; err != nil {
-		tx.Rollback()
-		return err
+	return "(" + strings.Join(terms, " OR ") + ")", args, nil
+}
+
+// keysetTieDesc tells whether profile_no ties are read descending.
+func keysetTieDesc(sort []dto.SortField, backward bool) bool {
+	return sort[len(sort)-1].Desc != backward
+}
+
+func keysetDirection(desc bool) string {
+	if desc {
+		return "DESC"
 	}
+	return "ASC"
+}
 
-	return tx.Commit().Error
+func keysetOperator(desc bool) string {
+	if desc {
+		return "<"
+	}
+	return ">"
 }
 
-func buildSQLParts(fields map[string]interface{}) (columns string, values string, args []interface{}) {
-	i := 1
-	for col, val := range fields {
-		if i > 1 {
-			columns += ", "
-			values += ", "
-		}
-		columns += col
-		values += fmt.Sprintf("$%d", i)
-		args = append(args, val)
-		i++
+// estimateUsers reads the planner's row estimate for the listing instead of
+// counting, which stays cheap on very large tables.
+func (r *userRepo) estimateUsers(ctx context.Context, filters []filter) (int64, error) {
+
+	var plan string
+
+	where, args := joinFilters(filters)
+
+	query := fmt.Sprintf(`
+		EXPLAIN (FORMAT JSON)
+		SELECT 1 FROM %s
+		%s`, r.from(ctx), where)
+
+	if err := database.Reader(ctx, r.db).Raw(query, args...).Row().Scan(&plan); err != nil {
+		return 0, err
 	}
-	return
+
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &explained); err != nil || len(explained) == 0 {
+		return 0, fmt.Errorf("failed to read the query plan: %v", err)
+	}
+
+	return int64(explained[0].Plan.Rows), nil
+}
+
+// roleNoById returns the role_no of an active role, or ErrUnknownRole. The
+// role stays share-locked until tx ends, so it cannot be deactivated while
+// a user is being assigned to it.
+func roleNoById(ctx context.Context, tx *gorm.DB, roleId uuid.UUID) (int, error) {
+
+	var roleNo int
+
+	query := fmt.Sprintf(`SELECT role_no FROM %s WHERE role_id = ? AND status = ? LIMIT 1 FOR SHARE`, database.Table(ctx, __ROLE_TBL__))
+
+	result := tx.Raw(query, roleId, models.Active).Scan(&roleNo)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return 0, interfaces.ErrUnknownRole
+	}
+
+	return roleNo, nil
 }
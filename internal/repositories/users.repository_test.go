@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+)
+
+func TestKeysetCondition(t *testing.T) {
+
+	name := userKeysetColumns["user_full_name"]
+	created := userKeysetColumns["created_at"]
+	dob := userKeysetColumns["dob"]
+
+	createdAt := time.Date(2024, 3, 1, 8, 30, 15, 123456000, time.UTC)
+	birthday := time.Date(1990, 7, 14, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		columns   []keysetColumn
+		sort      []dto.SortField
+		values    string
+		backward  bool
+		condition string
+		args      []interface{}
+	}{
+		{
+			name:    "one ascending key",
+			columns: []keysetColumn{name},
+			sort:    []dto.SortField{{Field: "user_full_name"}},
+			values:  `["Ann"]`,
+			condition: "((profile.user_full_name > ?) OR " +
+				"(profile.user_full_name = ? AND profile.profile_no > ?))",
+			args: []interface{}{"Ann", "Ann", int64(42)},
+		},
+		{
+			name:     "one ascending key, backward",
+			columns:  []keysetColumn{name},
+			sort:     []dto.SortField{{Field: "user_full_name"}},
+			values:   `["Ann"]`,
+			backward: true,
+			condition: "(((profile.user_full_name < ? OR profile.user_full_name IS NULL)) OR " +
+				"(profile.user_full_name = ? AND profile.profile_no < ?))",
+			args: []interface{}{"Ann", "Ann", int64(42)},
+		},
+		{
+			name:    "descending timestamp then ascending date",
+			columns: []keysetColumn{created, dob},
+			sort:    []dto.SortField{{Field: "created_at", Desc: true}, {Field: "dob"}},
+			values:  `["2024-03-01T08:30:15.123456Z", "1990-07-14"]`,
+			condition: "(((profile.created_at < ? OR profile.created_at IS NULL)) OR " +
+				"(profile.created_at = ? AND profile.dob > ?) OR " +
+				"(profile.created_at = ? AND profile.dob = ? AND profile.profile_no > ?))",
+			args: []interface{}{createdAt, createdAt, birthday, createdAt, birthday, int64(42)},
+		},
+		{
+			name:     "descending key, backward",
+			columns:  []keysetColumn{name},
+			sort:     []dto.SortField{{Field: "user_full_name", Desc: true}},
+			values:   `["Ann"]`,
+			backward: true,
+			condition: "((profile.user_full_name > ?) OR " +
+				"(profile.user_full_name = ? AND profile.profile_no > ?))",
+			args: []interface{}{"Ann", "Ann", int64(42)},
+		},
+		{
+			name:    "NULL key, ascending",
+			columns: []keysetColumn{dob},
+			sort:    []dto.SortField{{Field: "dob"}},
+			values:  `[null]`,
+			condition: "((profile.dob IS NOT NULL) OR " +
+				"(profile.dob IS NULL AND profile.profile_no > ?))",
+			args: []interface{}{int64(42)},
+		},
+		{
+			name:      "NULL key, descending",
+			columns:   []keysetColumn{dob},
+			sort:      []dto.SortField{{Field: "dob", Desc: true}},
+			values:    `[null]`,
+			condition: "((profile.dob IS NULL AND profile.profile_no < ?))",
+			args:      []interface{}{int64(42)},
+		},
+		{
+			name:    "NULL first key, descending then ascending",
+			columns: []keysetColumn{dob, name},
+			sort:    []dto.SortField{{Field: "dob", Desc: true}, {Field: "user_full_name"}},
+			values:  `[null, "Ann"]`,
+			condition: "((profile.dob IS NULL AND profile.user_full_name > ?) OR " +
+				"(profile.dob IS NULL AND profile.user_full_name = ? AND profile.profile_no > ?))",
+			args: []interface{}{"Ann", "Ann", int64(42)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			cursor := &dto.Cursor{Values: json.RawMessage(tt.values), Id: 42}
+
+			condition, args, err := keysetCondition(tt.columns, tt.sort, cursor, tt.backward)
+			if err != nil {
+				t.Fatalf("keysetCondition() error = %v", err)
+			}
+			if condition != tt.condition {
+				t.Errorf("condition =\n%s\nwant\n%s", condition, tt.condition)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %v, want %v", args, tt.args)
+			}
+		})
+	}
+}
+
+func TestKeysetConditionRejectsForeignCursors(t *testing.T) {
+
+	columns := []keysetColumn{userKeysetColumns["user_full_name"], userKeysetColumns["created_at"]}
+	sort := []dto.SortField{{Field: "user_full_name"}, {Field: "created_at"}}
+
+	tests := []struct {
+		name   string
+		values string
+	}{
+		{"not json", `Ann`},
+		{"not an array of strings", `[1, 2]`},
+		{"not an array", `{"a": "Ann"}`},
+		{"too few values", `["Ann"]`},
+		{"too many values", `["Ann", "2024-03-01T08:30:15Z", "x"]`},
+		{"bad timestamp", `["Ann", "2024-03-01"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			cursor := &dto.Cursor{Values: json.RawMessage(tt.values), Id: 1}
+
+			_, _, err := keysetCondition(columns, sort, cursor, false)
+			if !errors.Is(err, interfaces.ErrInvalidCursor) {
+				t.Errorf("keysetCondition() error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestKeysetColumnParse(t *testing.T) {
+
+	tests := []struct {
+		column string
+		value  string
+		want   interface{}
+	}{
+		{"user_full_name", "Ann", "Ann"},
+		{"dob", "1990-07-14", time.Date(1990, 7, 14, 0, 0, 0, 0, time.UTC)},
+		{"created_at", "2024-03-01T08:30:15.000001Z", time.Date(2024, 3, 1, 8, 30, 15, 1000, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.column, func(t *testing.T) {
+
+			got, err := userKeysetColumns[tt.column].parse(tt.value)
+			if err != nil {
+				t.Fatalf("parse(%q) error = %v", tt.value, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parse(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
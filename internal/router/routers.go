@@ -1,27 +1,121 @@
 package router
 
 import (
+	"net/http"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
 	controllers "github.com/chand-magar/SolidBaseGoStructure/internal/controllers"
+	database "github.com/chand-magar/SolidBaseGoStructure/internal/database"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/middleware"
 	repositories "github.com/chand-magar/SolidBaseGoStructure/internal/repositories"
 	services "github.com/chand-magar/SolidBaseGoStructure/internal/services"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func AllRouter(db *gorm.DB) *gin.Engine {
+func AllRouter(db *gorm.DB, cfg *config.Config) *gin.Engine {
+
+	r := gin.New()
+
+	txManager := database.NewTxManager(db)
 
-	r := gin.Default()
+	tenantRepo := repositories.NewTenantRepository(db)
+	tenantService := services.NewTenantService(tenantRepo, database.NewTenantProvisioner(db))
+
+	r.Use(
+		gin.Logger(),
+		middleware.RequestID(),
+		gin.CustomRecovery(func(c *gin.Context, _ any) {
+			utils.AbortWithProblem(c, http.StatusInternalServerError, "Internal server error")
+		}),
+		middleware.HandleErrors(),
+		middleware.RequestTimeout(cfg.RequestTimeout),
+		middleware.PinPrimaryForWrites(),
+		middleware.ResolveTenant(tenantService, cfg.Tenancy),
+	)
 
 	userRepo := repositories.NewUserRepository(db)
-	userService := services.NewUserService(userRepo)
+	userService := services.NewUserService(userRepo, cfg.UserPurgeRetention)
 	userController := controllers.NewUserController(userService)
 
-	users := r.Group("/v1/webmaster")
+	permissionRepo := repositories.NewPermissionRepository(db)
+	permissionService := services.NewPermissionService(permissionRepo)
+	authorizer := middleware.NewAuthorizer(permissionService)
+
+	roleRepo := repositories.NewRoleRepository(db)
+	roleService := services.NewRoleService(roleRepo, permissionService, txManager)
+	roleController := controllers.NewRoleController(roleService)
+
+	pageRepo := repositories.NewPageRepository(db)
+	pageService := services.NewPageService(pageRepo, txManager)
+	pageController := controllers.NewPageController(pageService)
+
+	sectionRepo := repositories.NewSectionRepository(db)
+	sectionService := services.NewSectionService(sectionRepo, pageRepo, txManager)
+	sectionController := controllers.NewSectionController(sectionService)
+
+	menuRepo := repositories.NewMenuRepository(db)
+	menuService := services.NewMenuService(menuRepo, permissionService)
+	menuController := controllers.NewMenuController(menuService)
+
+	authRepo := repositories.NewAuthRepository(db)
+	authService := services.NewAuthService(authRepo)
+	authController := controllers.NewAuthController(authService)
+
+	systemRepo := repositories.NewSystemRepository(db)
+	systemService := services.NewSystemService(systemRepo)
+	systemController := controllers.NewSystemController(systemService)
+
+	auth := r.Group("/v1/auth")
+	{
+		auth.POST("/login", authController.Login)
+		auth.POST("/refresh", authController.Refresh)
+		auth.POST("/logout", authController.Logout)
+	}
+
+	r.GET("/.well-known/jwks.json", authController.JWKS)
+	r.GET("/readyz", systemController.Ready)
+
+	webmaster := r.Group("/v1/webmaster", middleware.Authenticate())
+	{
+		webmaster.POST("/users", authorizer.RequirePermission("employees", dto.ActionCreate), userController.Create)
+		webmaster.GET("/users", authorizer.RequirePermission("employees", dto.ActionView), userController.GetAll)
+		webmaster.GET("/users/:id", authorizer.RequirePermission("employees", dto.ActionView), userController.FindOne)
+		webmaster.PUT("/users/:id", authorizer.RequirePermission("employees", dto.ActionUpdate), userController.Update)
+		webmaster.DELETE("/users/purge", authorizer.RequirePermission("employees", dto.ActionPurge), userController.Purge)
+		webmaster.DELETE("/users/:id", authorizer.RequirePermission("employees", dto.ActionDelete), userController.Delete)
+		webmaster.POST("/users/:id/restore", authorizer.RequirePermission("employees", dto.ActionUpdate), userController.Restore)
+
+		webmaster.POST("/roles", authorizer.RequirePermission("roles", dto.ActionCreate), roleController.Create)
+		webmaster.GET("/roles", authorizer.RequirePermission("roles", dto.ActionView), roleController.GetAll)
+		webmaster.GET("/roles/:id", authorizer.RequirePermission("roles", dto.ActionView), roleController.FindOne)
+		webmaster.PUT("/roles/:id", authorizer.RequirePermission("roles", dto.ActionUpdate), roleController.Update)
+		webmaster.POST("/roles/:id/clone", authorizer.RequirePermission("roles", dto.ActionCreate), roleController.Clone)
+		webmaster.PATCH("/roles/:id/status", authorizer.RequirePermission("roles", dto.ActionUpdate), roleController.UpdateStatus)
+
+		webmaster.POST("/sections", middleware.DefaultTenantOnly(), authorizer.RequirePermission("section", dto.ActionCreate), sectionController.Create)
+		webmaster.GET("/sections", authorizer.RequirePermission("section", dto.ActionView), sectionController.GetAll)
+		webmaster.PUT("/sections/reorder", middleware.DefaultTenantOnly(), authorizer.RequirePermission("section", dto.ActionUpdate), sectionController.Reorder)
+		webmaster.GET("/sections/:id", authorizer.RequirePermission("section", dto.ActionView), sectionController.FindOne)
+		webmaster.PUT("/sections/:id", middleware.DefaultTenantOnly(), authorizer.RequirePermission("section", dto.ActionUpdate), sectionController.Update)
+		webmaster.DELETE("/sections/:id", middleware.DefaultTenantOnly(), authorizer.RequirePermission("section", dto.ActionDelete), sectionController.Delete)
+
+		webmaster.POST("/sections/:id/pages", middleware.DefaultTenantOnly(), authorizer.RequirePermission("pages", dto.ActionCreate), pageController.Create)
+		webmaster.GET("/sections/:id/pages", authorizer.RequirePermission("pages", dto.ActionView), pageController.GetBySection)
+		webmaster.PUT("/sections/:id/pages/reorder", middleware.DefaultTenantOnly(), authorizer.RequirePermission("pages", dto.ActionUpdate), pageController.Reorder)
+		webmaster.GET("/pages/:id", authorizer.RequirePermission("pages", dto.ActionView), pageController.FindOne)
+		webmaster.PUT("/pages/:id", middleware.DefaultTenantOnly(), authorizer.RequirePermission("pages", dto.ActionUpdate), pageController.Update)
+		webmaster.DELETE("/pages/:id", middleware.DefaultTenantOnly(), authorizer.RequirePermission("pages", dto.ActionDelete), pageController.Delete)
+		webmaster.PATCH("/pages/:id/move", middleware.DefaultTenantOnly(), authorizer.RequirePermission("pages", dto.ActionUpdate), pageController.Move)
+
+		webmaster.GET("/system/database", middleware.DefaultTenantOnly(), authorizer.RequirePermission("system", dto.ActionView), systemController.DatabaseStats)
+	}
+
+	me := r.Group("/v1/me", middleware.Authenticate())
 	{
-		users.POST("/users", userController.Create)
-		users.GET("/users", userController.GetAll)
-		users.GET("/users/:id", userController.FindOne)
-		users.PUT("/users/:id", userController.Update)
+		me.GET("/menu", menuController.GetMenu)
 	}
 
 	r.GET("/", func(c *gin.Context) {
@@ -29,7 +123,7 @@ func AllRouter(db *gorm.DB) *gin.Engine {
 	})
 
 	r.NoRoute(func(c *gin.Context) {
-		c.JSON(404, gin.H{"message": "Unable to find the specified API"})
+		utils.AbortWithProblem(c, http.StatusNotFound, "Unable to find the specified API")
 	})
 
 	return r
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/google/uuid"
+)
+
+// dummyHash is compared against when the username does not exist so that
+// unknown users take as long to reject as wrong passwords.
+var dummyHash, _ = utils.HashPassword("dummy-password-for-timing")
+
+type authService struct {
+	repo interfaces.AuthRepository
+}
+
+func NewAuthService(repo interfaces.AuthRepository) interfaces.AuthService {
+	return &authService{repo: repo}
+}
+
+func (s *authService) Login(ctx context.Context, data dto.LoginRequestDTO) (*dto.LoginResponseDTO, error) {
+
+	cred, err := s.repo.FindCredentialByUsername(ctx, data.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if cred == nil {
+		utils.CheckPassword(dummyHash, data.Password)
+		return nil, interfaces.ErrInvalidCredentials
+	}
+
+	if !utils.CheckPassword(cred.Password, data.Password) {
+		return nil, interfaces.ErrInvalidCredentials
+	}
+
+	if cred.Status != models.Active {
+		return nil, interfaces.ErrInactiveCredential
+	}
+
+	refreshToken, refreshHash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	record := newRefreshToken(refreshHash, data.UserAgent, data.IpAddress)
+	record.FamilyId = uuid.New()
+	record.ProfileNo = cred.ProfileNo
+
+	if err := s.repo.CreateRefreshToken(ctx, record); err != nil {
+		return nil, err
+	}
+
+	return issueTokens(ctx, cred, refreshToken)
+}
+
+func (s *authService) Refresh(ctx context.Context, data dto.RefreshRequestDTO) (*dto.LoginResponseDTO, error) {
+
+	refreshToken, refreshHash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	next := newRefreshToken(refreshHash, data.UserAgent, data.IpAddress)
+
+	current, err := s.repo.RotateRefreshToken(ctx, utils.HashRefreshToken(data.RefreshToken), next)
+	if err != nil {
+		return nil, err
+	}
+
+	cred, err := s.repo.FindCredentialByProfileNo(ctx, current.ProfileNo)
+	if err != nil {
+		return nil, err
+	}
+
+	if cred == nil || cred.Status != models.Active {
+		if err := s.repo.RevokeRefreshTokenFamily(ctx, refreshHash); err != nil {
+			return nil, err
+		}
+		return nil, interfaces.ErrInactiveCredential
+	}
+
+	return issueTokens(ctx, cred, refreshToken)
+}
+
+func (s *authService) Logout(ctx context.Context, data dto.RefreshRequestDTO) error {
+
+	return s.repo.RevokeRefreshTokenFamily(ctx, utils.HashRefreshToken(data.RefreshToken))
+}
+
+func newRefreshToken(hash, userAgent, ipAddress string) *models.RefreshToken {
+	return &models.RefreshToken{
+		TokenId:   uuid.New(),
+		TokenHash: hash,
+		UserAgent: userAgent,
+		IpAddress: ipAddress,
+		ExpiresAt: time.Now().UTC().Add(utils.RefreshTokenTTL),
+	}
+}
+
+func issueTokens(ctx context.Context, cred *dto.CredentialDTO, refreshToken string) (*dto.LoginResponseDTO, error) {
+
+	claims := utils.UserClaims{
+		ProfileNo: cred.ProfileNo,
+		ProfileId: cred.ProfileId,
+		RoleId:    cred.RoleId,
+		Role:      cred.RoleName,
+	}
+	if tenant, ok := utils.TenantFromContext(ctx); ok {
+		claims.Tenant = tenant.Slug
+	}
+
+	token, err := utils.CreateToken(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResponseDTO{
+		AccessToken:  token,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(utils.AccessTokenTTL.Seconds()),
+	}, nil
+}
@@ -0,0 +1,373 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/utils"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakeAuthRepo keeps credentials by username and refresh tokens by hash, and
+// rotates and revokes them the way AuthRepository documents.
+type fakeAuthRepo struct {
+	credentials map[string]*dto.CredentialDTO
+
+	tokens  map[string]*models.RefreshToken
+	created []*models.RefreshToken
+	revoked []string
+}
+
+func (r *fakeAuthRepo) FindCredentialByUsername(ctx context.Context, username string) (*dto.CredentialDTO, error) {
+	return r.credentials[username], nil
+}
+
+func (r *fakeAuthRepo) FindCredentialByProfileNo(ctx context.Context, profileNo uint32) (*dto.CredentialDTO, error) {
+	for _, cred := range r.credentials {
+		if cred.ProfileNo == profileNo {
+			return cred, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeAuthRepo) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) error {
+	if r.tokens == nil {
+		r.tokens = map[string]*models.RefreshToken{}
+	}
+	r.tokens[token.TokenHash] = token
+	r.created = append(r.created, token)
+	return nil
+}
+
+func (r *fakeAuthRepo) RotateRefreshToken(ctx context.Context, hash string, next *models.RefreshToken) (*models.RefreshToken, error) {
+
+	current, ok := r.tokens[hash]
+	if !ok {
+		return nil, interfaces.ErrInvalidRefreshToken
+	}
+
+	now := time.Now().UTC()
+
+	if current.UsedAt != nil {
+		r.revokeFamily(current.FamilyId, now)
+		return nil, interfaces.ErrRefreshTokenReused
+	}
+
+	if current.RevokedAt != nil || now.After(current.ExpiresAt) {
+		return nil, interfaces.ErrInvalidRefreshToken
+	}
+
+	current.UsedAt = &now
+
+	next.FamilyId = current.FamilyId
+	next.ProfileNo = current.ProfileNo
+
+	return current, r.CreateRefreshToken(ctx, next)
+}
+
+func (r *fakeAuthRepo) RevokeRefreshTokenFamily(ctx context.Context, hash string) error {
+	r.revoked = append(r.revoked, hash)
+	if token, ok := r.tokens[hash]; ok {
+		r.revokeFamily(token.FamilyId, time.Now().UTC())
+	}
+	return nil
+}
+
+func (r *fakeAuthRepo) revokeFamily(familyId uuid.UUID, now time.Time) {
+	for _, token := range r.tokens {
+		if token.FamilyId == familyId && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+}
+
+func initTestJWT(t *testing.T) {
+	t.Helper()
+
+	err := utils.InitJWT(config.JWT{
+		Issuer:          "test",
+		Audience:        "test-api",
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+		SigningKid:      "test",
+		Keys:            []config.SigningKey{{Kid: "test", Algorithm: "HS256", Secret: strings.Repeat("s", 32)}},
+	})
+	if err != nil {
+		t.Fatalf("InitJWT() error = %v", err)
+	}
+}
+
+func newTestCredential(t *testing.T, profileNo uint32, password string, status models.StatusEnum) *dto.CredentialDTO {
+	t.Helper()
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	return &dto.CredentialDTO{
+		ProfileNo: profileNo,
+		ProfileId: uuid.New(),
+		Password:  hash,
+		Status:    status,
+		RoleId:    uuid.New(),
+		RoleName:  "Editors",
+	}
+}
+
+func TestLogin(t *testing.T) {
+
+	initTestJWT(t)
+
+	active := newTestCredential(t, 1, "correct horse", models.Active)
+	inactive := newTestCredential(t, 2, "correct horse", models.Inactive)
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		err      error
+	}{
+		{"unknown user", "nobody", "correct horse", interfaces.ErrInvalidCredentials},
+		{"wrong password", "ann", "wrong", interfaces.ErrInvalidCredentials},
+		{"inactive credential", "bob", "correct horse", interfaces.ErrInactiveCredential},
+		{"inactive credential, wrong password", "bob", "wrong", interfaces.ErrInvalidCredentials},
+		{"active credential", "ann", "correct horse", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			repo := &fakeAuthRepo{credentials: map[string]*dto.CredentialDTO{"ann": active, "bob": inactive}}
+			service := NewAuthService(repo)
+
+			response, err := service.Login(context.Background(), dto.LoginRequestDTO{Username: tt.username, Password: tt.password})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Login() error = %v, want %v", err, tt.err)
+			}
+
+			if tt.err != nil {
+				if len(repo.created) != 0 {
+					t.Errorf("a refresh token was stored for a failed login")
+				}
+				return
+			}
+
+			if len(repo.created) != 1 {
+				t.Fatalf("stored %d refresh tokens, want 1", len(repo.created))
+			}
+			stored := repo.created[0]
+			if stored.ProfileNo != active.ProfileNo || stored.FamilyId == uuid.Nil {
+				t.Errorf("stored token profile_no = %d, family = %v", stored.ProfileNo, stored.FamilyId)
+			}
+			if stored.TokenHash != utils.HashRefreshToken(response.RefreshToken) {
+				t.Errorf("stored hash does not match the returned refresh token")
+			}
+
+			claims, err := utils.VerifyToken(response.AccessToken)
+			if err != nil {
+				t.Fatalf("VerifyToken() error = %v", err)
+			}
+			if claims.ProfileNo != active.ProfileNo || claims.RoleId != active.RoleId {
+				t.Errorf("claims = %+v", claims)
+			}
+		})
+	}
+}
+
+// Unknown users are checked against dummyHash, which must cost as much as a
+// real password hash or the response time tells them apart.
+func TestLoginDummyHashCost(t *testing.T) {
+
+	hashed, err := utils.HashPassword("password")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	realCost, _ := bcrypt.Cost([]byte(hashed))
+	dummyCost, err := bcrypt.Cost([]byte(dummyHash))
+	if err != nil {
+		t.Fatalf("dummyHash is not a bcrypt hash: %v", err)
+	}
+
+	if dummyCost != realCost {
+		t.Errorf("dummyHash cost = %d, want %d", dummyCost, realCost)
+	}
+}
+
+func TestLoginUnknownUserTakesAsLongAsWrongPassword(t *testing.T) {
+
+	repo := &fakeAuthRepo{credentials: map[string]*dto.CredentialDTO{"ann": newTestCredential(t, 1, "correct horse", models.Active)}}
+	service := NewAuthService(repo)
+
+	timeLogin := func(username string) time.Duration {
+		started := time.Now()
+		service.Login(context.Background(), dto.LoginRequestDTO{Username: username, Password: "wrong"})
+		return time.Since(started)
+	}
+
+	// The fastest of a few attempts keeps scheduler noise out.
+	fastest := func(username string) time.Duration {
+		best := timeLogin(username)
+		for i := 0; i < 2; i++ {
+			best = min(best, timeLogin(username))
+		}
+		return best
+	}
+
+	unknown, wrong := fastest("nobody"), fastest("ann")
+	if unknown < wrong/2 {
+		t.Errorf("unknown user rejected in %v, wrong password in %v", unknown, wrong)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+
+	initTestJWT(t)
+
+	ann := newTestCredential(t, 1, "correct horse", models.Active)
+
+	repo := &fakeAuthRepo{credentials: map[string]*dto.CredentialDTO{"ann": ann}}
+	service := NewAuthService(repo)
+
+	login, err := service.Login(context.Background(), dto.LoginRequestDTO{Username: "ann", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	first, err := service.Refresh(context.Background(), dto.RefreshRequestDTO{RefreshToken: login.RefreshToken})
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if first.RefreshToken == login.RefreshToken {
+		t.Fatalf("Refresh() returned the presented refresh token")
+	}
+
+	if len(repo.created) != 2 {
+		t.Fatalf("stored %d refresh tokens, want 2", len(repo.created))
+	}
+	original, next := repo.created[0], repo.created[1]
+	if next.TokenHash != utils.HashRefreshToken(first.RefreshToken) {
+		t.Errorf("stored hash does not match the returned refresh token")
+	}
+	if next.FamilyId != original.FamilyId || next.ProfileNo != ann.ProfileNo {
+		t.Errorf("rotated token family = %v, profile_no = %d; want %v, %d", next.FamilyId, next.ProfileNo, original.FamilyId, ann.ProfileNo)
+	}
+	if original.UsedAt == nil {
+		t.Errorf("the presented token was not marked as used")
+	}
+
+	claims, err := utils.VerifyToken(first.AccessToken)
+	if err != nil {
+		t.Fatalf("VerifyToken() error = %v", err)
+	}
+	if claims.ProfileNo != ann.ProfileNo {
+		t.Errorf("claims profile_no = %d, want %d", claims.ProfileNo, ann.ProfileNo)
+	}
+
+	// Replaying the exchanged token revokes the family, so the token the
+	// legitimate client holds stops working as well.
+	_, err = service.Refresh(context.Background(), dto.RefreshRequestDTO{RefreshToken: login.RefreshToken})
+	if !errors.Is(err, interfaces.ErrRefreshTokenReused) {
+		t.Fatalf("replayed Refresh() error = %v, want %v", err, interfaces.ErrRefreshTokenReused)
+	}
+	if next.RevokedAt == nil {
+		t.Errorf("the family was not revoked on reuse")
+	}
+
+	_, err = service.Refresh(context.Background(), dto.RefreshRequestDTO{RefreshToken: first.RefreshToken})
+	if !errors.Is(err, interfaces.ErrInvalidRefreshToken) {
+		t.Errorf("Refresh() after reuse error = %v, want %v", err, interfaces.ErrInvalidRefreshToken)
+	}
+}
+
+func TestRefreshRejects(t *testing.T) {
+
+	initTestJWT(t)
+
+	expired := time.Now().UTC().Add(-time.Minute)
+	revoked := time.Now().UTC()
+
+	tests := []struct {
+		name   string
+		status models.StatusEnum
+		token  func(*models.RefreshToken)
+		err    error
+		revoke bool
+	}{
+		{"unknown token", models.Active, nil, interfaces.ErrInvalidRefreshToken, false},
+		{"expired token", models.Active, func(token *models.RefreshToken) { token.ExpiresAt = expired }, interfaces.ErrInvalidRefreshToken, false},
+		{"revoked token", models.Active, func(token *models.RefreshToken) { token.RevokedAt = &revoked }, interfaces.ErrInvalidRefreshToken, false},
+		{"inactive credential", models.Inactive, func(token *models.RefreshToken) {}, interfaces.ErrInactiveCredential, true},
+		{"deleted profile", models.Active, func(token *models.RefreshToken) { token.ProfileNo = 99 }, interfaces.ErrInactiveCredential, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			repo := &fakeAuthRepo{credentials: map[string]*dto.CredentialDTO{"ann": newTestCredential(t, 1, "correct horse", tt.status)}}
+			service := NewAuthService(repo)
+
+			refreshToken, refreshHash, err := utils.GenerateRefreshToken()
+			if err != nil {
+				t.Fatalf("GenerateRefreshToken() error = %v", err)
+			}
+
+			if tt.token != nil {
+				token := newRefreshToken(refreshHash, "", "")
+				token.FamilyId = uuid.New()
+				token.ProfileNo = 1
+				tt.token(token)
+				repo.CreateRefreshToken(context.Background(), token)
+			}
+
+			_, err = service.Refresh(context.Background(), dto.RefreshRequestDTO{RefreshToken: refreshToken})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Refresh() error = %v, want %v", err, tt.err)
+			}
+
+			if !tt.revoke {
+				if len(repo.revoked) != 0 {
+					t.Errorf("revoked %v, want nothing", repo.revoked)
+				}
+				return
+			}
+
+			// The rotated token is the newest of the family; revoking through
+			// it must reach the presented one too.
+			if len(repo.revoked) != 1 || repo.revoked[0] != repo.created[len(repo.created)-1].TokenHash {
+				t.Errorf("revoked %v, want the hash of the rotated token", repo.revoked)
+			}
+			for _, token := range repo.created {
+				if token.RevokedAt == nil {
+					t.Errorf("token %s of the family was left active", token.TokenHash)
+				}
+			}
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+
+	repo := &fakeAuthRepo{}
+	service := NewAuthService(repo)
+
+	if err := service.Logout(context.Background(), dto.RefreshRequestDTO{RefreshToken: "presented"}); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+
+	want := utils.HashRefreshToken("presented")
+	if len(repo.revoked) != 1 || repo.revoked[0] != want {
+		t.Errorf("revoked %v, want [%s]", repo.revoked, want)
+	}
+}
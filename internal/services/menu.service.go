@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/google/uuid"
+)
+
+type menuService struct {
+	repo        interfaces.MenuRepository
+	permissions interfaces.PermissionService
+}
+
+func NewMenuService(repo interfaces.MenuRepository, permissions interfaces.PermissionService) interfaces.MenuService {
+	return &menuService{repo: repo, permissions: permissions}
+}
+
+func (s *menuService) GetMenu(ctx context.Context, roleId uuid.UUID) ([]dto.MenuSectionDTO, error) {
+
+	rows, err := s.repo.GetActiveRows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	menu := []dto.MenuSectionDTO{}
+
+	for _, row := range rows {
+
+		allowed, err := s.permissions.HasPermission(ctx, roleId, row.PagePath, dto.ActionView)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			continue
+		}
+
+		// Rows arrive grouped by section, so a new section starts whenever
+		// the id changes. Sections without visible pages are never added.
+		if len(menu) == 0 || menu[len(menu)-1].SectionId != row.SectionId {
+			menu = append(menu, dto.MenuSectionDTO{
+				SectionId:    row.SectionId,
+				SectionName:  row.SectionName,
+				SectionPath:  row.SectionPath,
+				SectionIcon:  row.SectionIcon,
+				SectionOrder: row.SectionOrder,
+				Pages:        []dto.MenuPageDTO{},
+			})
+		}
+
+		section := &menu[len(menu)-1]
+		section.Pages = append(section.Pages, dto.MenuPageDTO{
+			PageId:    row.PageId,
+			PageName:  row.PageName,
+			PagePath:  row.PagePath,
+			PageOrder: row.PageOrder,
+			Path:      "/" + row.SectionPath + "/" + row.PagePath,
+		})
+	}
+
+	return menu, nil
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/google/uuid"
+)
+
+type pageService struct {
+	repo interfaces.PageRepository
+	tx   interfaces.Transactor
+}
+
+func NewPageService(repo interfaces.PageRepository, tx interfaces.Transactor) interfaces.PageService {
+	return &pageService{repo: repo, tx: tx}
+}
+
+func (s *pageService) Create(ctx context.Context, sectionId uuid.UUID, data dto.PageRequestDTO) (uuid.UUID, error) {
+
+	var id uuid.UUID
+
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+
+		if err := s.ensurePathAvailable(ctx, data.PagePath, uuid.Nil); err != nil {
+			return err
+		}
+
+		var err error
+		id, err = s.repo.Create(ctx, sectionId, data)
+		return err
+	})
+
+	return id, err
+}
+
+func (s *pageService) GetBySection(ctx context.Context, sectionId uuid.UUID) ([]dto.PageResponseDTO, error) {
+	return s.repo.GetBySection(ctx, sectionId)
+}
+
+func (s *pageService) FindOne(ctx context.Context, id uuid.UUID) (*dto.PageResponseDTO, error) {
+	return s.repo.FindOne(ctx, id)
+}
+
+func (s *pageService) Update(ctx context.Context, id uuid.UUID, data dto.PageRequestDTO) error {
+
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+
+		if data.PagePath != "" {
+			if err := s.ensurePathAvailable(ctx, data.PagePath, id); err != nil {
+				return err
+			}
+		}
+
+		return s.repo.Update(ctx, id, data)
+	})
+}
+
+func (s *pageService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *pageService) Reorder(ctx context.Context, sectionId uuid.UUID, data dto.ReorderDTO) error {
+	return s.repo.Reorder(ctx, sectionId, data)
+}
+
+func (s *pageService) Move(ctx context.Context, id uuid.UUID, data dto.PageMoveDTO) error {
+	return s.repo.Move(ctx, id, data)
+}
+
+// ensurePathAvailable keeps page_path unique because permissions reference
+// pages by path.
+func (s *pageService) ensurePathAvailable(ctx context.Context, path string, excludeId uuid.UUID) error {
+
+	exists, err := s.repo.ExistsByPath(ctx, path, excludeId)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return interfaces.ErrPagePathTaken
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+)
+
+// permissionCacheTTL bounds how stale a role can be on replicas that did not
+// see the change that invalidated it.
+const permissionCacheTTL = 5 * time.Minute
+
+type cachedPermissions struct {
+	permissions dto.PermissionSet
+	expiresAt   time.Time
+}
+
+type permissionService struct {
+	repo  interfaces.PermissionRepository
+	mu    sync.RWMutex
+	cache map[uuid.UUID]cachedPermissions
+}
+
+func NewPermissionService(repo interfaces.PermissionRepository) interfaces.PermissionService {
+	return &permissionService{
+		repo:  repo,
+		cache: map[uuid.UUID]cachedPermissions{},
+	}
+}
+
+func (s *permissionService) HasPermission(ctx context.Context, roleId uuid.UUID, page, action string) (bool, error) {
+
+	permissions, err := s.permissions(ctx, roleId)
+	if err != nil {
+		return false, err
+	}
+
+	return permissions.Allows(page, action), nil
+}
+
+func (s *permissionService) Invalidate(roleId uuid.UUID) {
+	s.mu.Lock()
+	delete(s.cache, roleId)
+	s.mu.Unlock()
+}
+
+func (s *permissionService) permissions(ctx context.Context, roleId uuid.UUID) (dto.PermissionSet, error) {
+
+	s.mu.RLock()
+	entry, ok := s.cache[roleId]
+	s.mu.RUnlock()
+
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.permissions, nil
+	}
+
+	role, err := s.repo.FindRolePermissions(ctx, roleId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Missing or inactive roles grant nothing.
+	permissions := dto.PermissionSet{}
+	if role != nil && role.Status == models.Active {
+		permissions = role.RoleDetails
+	}
+
+	s.mu.Lock()
+	s.cache[roleId] = cachedPermissions{
+		permissions: permissions,
+		expiresAt:   time.Now().Add(permissionCacheTTL),
+	}
+	s.mu.Unlock()
+
+	return permissions, nil
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+)
+
+// fakePermissionRepo serves roles from a map and counts the lookups that
+// reach it.
+type fakePermissionRepo struct {
+	roles map[uuid.UUID]*dto.RolePermissionsDTO
+	err   error
+	calls int
+}
+
+func (r *fakePermissionRepo) FindRolePermissions(ctx context.Context, roleId uuid.UUID) (*dto.RolePermissionsDTO, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.roles[roleId], nil
+}
+
+func TestHasPermission(t *testing.T) {
+
+	editor, admin, viewer, inactive := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	repo := &fakePermissionRepo{roles: map[uuid.UUID]*dto.RolePermissionsDTO{
+		editor: {Status: models.Active, RoleDetails: dto.PermissionSet{
+			{PagePath: "/pages", Actions: []string{dto.ActionView, dto.ActionUpdate}},
+			{PagePath: "/sections", Actions: []string{dto.PermissionWildcard}},
+		}},
+		admin: {Status: models.Active, RoleDetails: dto.PermissionSet{
+			{PagePath: dto.PermissionWildcard, Actions: []string{dto.PermissionWildcard}},
+		}},
+		viewer: {Status: models.Active, RoleDetails: dto.PermissionSet{
+			{PagePath: dto.PermissionWildcard, Actions: []string{dto.ActionView}},
+		}},
+		inactive: {Status: models.Inactive, RoleDetails: dto.PermissionSet{
+			{PagePath: dto.PermissionWildcard, Actions: []string{dto.PermissionWildcard}},
+		}},
+	}}
+	service := NewPermissionService(repo)
+
+	tests := []struct {
+		name   string
+		roleId uuid.UUID
+		page   string
+		action string
+		want   bool
+	}{
+		{"granted action", editor, "/pages", dto.ActionUpdate, true},
+		{"action not granted", editor, "/pages", dto.ActionDelete, false},
+		{"page not granted", editor, "/users", dto.ActionView, false},
+		{"wildcard action", editor, "/sections", dto.ActionPurge, true},
+		{"wildcard page and action", admin, "/users", dto.ActionDelete, true},
+		{"wildcard page", viewer, "/users", dto.ActionView, true},
+		{"wildcard page, action not granted", viewer, "/users", dto.ActionCreate, false},
+		{"inactive role grants nothing", inactive, "/users", dto.ActionView, false},
+		{"missing role grants nothing", uuid.New(), "/users", dto.ActionView, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			got, err := service.HasPermission(context.Background(), tt.roleId, tt.page, tt.action)
+			if err != nil {
+				t.Fatalf("HasPermission() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HasPermission(%q, %q) = %v, want %v", tt.page, tt.action, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermissionCache(t *testing.T) {
+
+	roleId := uuid.New()
+	role := &dto.RolePermissionsDTO{Status: models.Active, RoleDetails: dto.PermissionSet{
+		{PagePath: "/pages", Actions: []string{dto.ActionView}},
+	}}
+
+	repo := &fakePermissionRepo{roles: map[uuid.UUID]*dto.RolePermissionsDTO{roleId: role}}
+	service := NewPermissionService(repo).(*permissionService)
+
+	allowed := func() bool {
+		t.Helper()
+		ok, err := service.HasPermission(context.Background(), roleId, "/pages", dto.ActionView)
+		if err != nil {
+			t.Fatalf("HasPermission() error = %v", err)
+		}
+		return ok
+	}
+
+	if !allowed() || !allowed() {
+		t.Fatalf("view on /pages was denied")
+	}
+	if repo.calls != 1 {
+		t.Fatalf("repository read %d times, want 1 while cached", repo.calls)
+	}
+
+	// A revoked grant stays cached until the role is invalidated.
+	role.RoleDetails = dto.PermissionSet{}
+	if !allowed() {
+		t.Errorf("cached grant was dropped before Invalidate")
+	}
+
+	service.Invalidate(roleId)
+	if allowed() {
+		t.Errorf("revoked grant still allowed after Invalidate")
+	}
+	if repo.calls != 2 {
+		t.Errorf("repository read %d times, want 2 after Invalidate", repo.calls)
+	}
+
+	// Replicas that missed the invalidation reload once the entry expires.
+	role.RoleDetails = dto.PermissionSet{{PagePath: "/pages", Actions: []string{dto.ActionView}}}
+
+	service.mu.Lock()
+	entry := service.cache[roleId]
+	entry.expiresAt = time.Now().Add(-time.Second)
+	service.cache[roleId] = entry
+	service.mu.Unlock()
+
+	if !allowed() {
+		t.Errorf("expired entry was not reloaded")
+	}
+	if repo.calls != 3 {
+		t.Errorf("repository read %d times, want 3 after expiry", repo.calls)
+	}
+}
+
+func TestPermissionCacheSkipsErrors(t *testing.T) {
+
+	roleId := uuid.New()
+	failure := errors.New("connection refused")
+
+	repo := &fakePermissionRepo{err: failure}
+	service := NewPermissionService(repo)
+
+	if _, err := service.HasPermission(context.Background(), roleId, "/pages", dto.ActionView); !errors.Is(err, failure) {
+		t.Fatalf("HasPermission() error = %v, want %v", err, failure)
+	}
+
+	repo.err = nil
+	repo.roles = map[uuid.UUID]*dto.RolePermissionsDTO{roleId: {Status: models.Active, RoleDetails: dto.PermissionSet{
+		{PagePath: "/pages", Actions: []string{dto.ActionView}},
+	}}}
+
+	ok, err := service.HasPermission(context.Background(), roleId, "/pages", dto.ActionView)
+	if err != nil || !ok {
+		t.Errorf("HasPermission() after a failed lookup = %v, %v; want true", ok, err)
+	}
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+)
+
+type roleService struct {
+	repo        interfaces.RoleRepository
+	permissions interfaces.PermissionService
+	tx          interfaces.Transactor
+}
+
+func NewRoleService(repo interfaces.RoleRepository, permissions interfaces.PermissionService, tx interfaces.Transactor) interfaces.RoleService {
+	return &roleService{repo: repo, permissions: permissions, tx: tx}
+}
+
+func (s *roleService) Create(ctx context.Context, data dto.RoleRequestDTO) (uuid.UUID, error) {
+
+	var id uuid.UUID
+
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+
+		if err := s.ensureNameAvailable(ctx, data.RoleName, uuid.Nil); err != nil {
+			return err
+		}
+
+		var err error
+		id, err = s.repo.Create(ctx, data)
+		return err
+	})
+
+	return id, err
+}
+
+func (s *roleService) GetAll(ctx context.Context, params dto.PaginationParams) (dto.PageResult[dto.RoleResponseDTO], error) {
+	return s.repo.List(ctx, params)
+}
+
+func (s *roleService) FindOne(ctx context.Context, id uuid.UUID) (*dto.RoleResponseDTO, error) {
+	return s.repo.FindOne(ctx, id)
+}
+
+func (s *roleService) FindByName(ctx context.Context, name string) (*dto.RoleResponseDTO, error) {
+	return s.repo.FindByName(ctx, name)
+}
+
+func (s *roleService) Update(ctx context.Context, id uuid.UUID, data dto.RoleRequestDTO) error {
+
+	// Status changes check the role's users first, see UpdateStatus.
+	if data.Status != "" {
+		return interfaces.ErrRoleStatusSet
+	}
+
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+
+		if data.RoleName != "" {
+			if err := s.ensureNameAvailable(ctx, data.RoleName, id); err != nil {
+				return err
+			}
+		}
+
+		return s.repo.Update(ctx, id, data)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.permissions.Invalidate(id)
+	return nil
+}
+
+func (s *roleService) Clone(ctx context.Context, id uuid.UUID, data dto.RoleCloneDTO) (uuid.UUID, error) {
+
+	var cloneId uuid.UUID
+
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+
+		source, err := s.repo.FindOne(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.ensureNameAvailable(ctx, data.RoleName, uuid.Nil); err != nil {
+			return err
+		}
+
+		cloneId, err = s.repo.Create(ctx, dto.RoleRequestDTO{
+			RoleName:    data.RoleName,
+			RoleDetails: source.RoleDetails,
+			Status:      models.Active,
+		})
+		return err
+	})
+
+	return cloneId, err
+}
+
+func (s *roleService) UpdateStatus(ctx context.Context, id uuid.UUID, data dto.RoleStatusDTO) error {
+
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+		return s.repo.UpdateStatus(ctx, id, data.Status)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.permissions.Invalidate(id)
+	return nil
+}
+
+func (s *roleService) ensureNameAvailable(ctx context.Context, name string, excludeId uuid.UUID) error {
+
+	exists, err := s.repo.ExistsByName(ctx, name, excludeId)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return interfaces.ErrRoleNameTaken
+	}
+
+	return nil
+}
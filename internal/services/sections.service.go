@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/google/uuid"
+)
+
+type sectionService struct {
+	repo  interfaces.SectionRepository
+	pages interfaces.PageRepository
+	tx    interfaces.Transactor
+}
+
+func NewSectionService(repo interfaces.SectionRepository, pages interfaces.PageRepository, tx interfaces.Transactor) interfaces.SectionService {
+	return &sectionService{repo: repo, pages: pages, tx: tx}
+}
+
+func (s *sectionService) Create(ctx context.Context, data dto.SectionRequestDTO) (uuid.UUID, error) {
+
+	var id uuid.UUID
+
+	err := s.tx.Do(ctx, func(ctx context.Context) error {
+
+		if err := s.ensurePathAvailable(ctx, data.SectionPath, uuid.Nil); err != nil {
+			return err
+		}
+
+		var err error
+		id, err = s.repo.Create(ctx, data)
+		return err
+	})
+
+	return id, err
+}
+
+func (s *sectionService) GetAll(ctx context.Context, params dto.PaginationParams) (dto.PageResult[dto.SectionResponseDTO], error) {
+	return s.repo.List(ctx, params)
+}
+
+func (s *sectionService) FindOne(ctx context.Context, id uuid.UUID) (*dto.SectionResponseDTO, error) {
+
+	section, err := s.repo.FindOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if section.Pages, err = s.pages.GetBySection(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+func (s *sectionService) Update(ctx context.Context, id uuid.UUID, data dto.SectionRequestDTO) error {
+
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+
+		if data.SectionPath != "" {
+			if err := s.ensurePathAvailable(ctx, data.SectionPath, id); err != nil {
+				return err
+			}
+		}
+
+		return s.repo.Update(ctx, id, data)
+	})
+}
+
+func (s *sectionService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *sectionService) Reorder(ctx context.Context, data dto.ReorderDTO) error {
+	return s.repo.Reorder(ctx, data)
+}
+
+func (s *sectionService) ensurePathAvailable(ctx context.Context, path string, excludeId uuid.UUID) error {
+
+	exists, err := s.repo.ExistsByPath(ctx, path, excludeId)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return interfaces.ErrSectionPathTaken
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+)
+
+type systemService struct {
+	repo interfaces.SystemRepository
+}
+
+func NewSystemService(repo interfaces.SystemRepository) interfaces.SystemService {
+	return &systemService{repo: repo}
+}
+
+func (s *systemService) DatabaseStats(ctx context.Context) (*dto.DatabaseStatsDTO, error) {
+	return s.repo.DatabaseStats(ctx)
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+	"github.com/google/uuid"
+)
+
+// tenantCacheTTL bounds how long a deactivated tenant keeps resolving on
+// this instance.
+const tenantCacheTTL = time.Minute
+
+// tenantSlugPattern keeps the derived schema name short enough for the
+// index names in the tenant migrations to fit PostgreSQL's 63 byte limit.
+var tenantSlugPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{1,19}$`)
+
+type cachedTenant struct {
+	tenant    *dto.TenantDTO
+	expiresAt time.Time
+}
+
+type tenantService struct {
+	repo        interfaces.TenantRepository
+	provisioner interfaces.TenantProvisioner
+	mu          sync.RWMutex
+	cache       map[string]cachedTenant
+}
+
+func NewTenantService(repo interfaces.TenantRepository, provisioner interfaces.TenantProvisioner) interfaces.TenantService {
+	return &tenantService{
+		repo:        repo,
+		provisioner: provisioner,
+		cache:       map[string]cachedTenant{},
+	}
+}
+
+func (s *tenantService) Create(ctx context.Context, data dto.TenantRequestDTO) (*dto.TenantDTO, error) {
+
+	slug := strings.ToLower(strings.TrimSpace(data.Slug))
+	if !tenantSlugPattern.MatchString(slug) {
+		return nil, interfaces.ErrInvalidTenantSlug
+	}
+
+	exists, err := s.repo.ExistsBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, interfaces.ErrTenantSlugTaken
+	}
+
+	tenant := dto.TenantDTO{
+		TenantId:   uuid.New(),
+		Slug:       slug,
+		TenantName: strings.TrimSpace(data.TenantName),
+		SchemaName: "tenant_" + strings.ReplaceAll(slug, "-", "_"),
+		Status:     models.Active,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	// The schema is provisioned before the tenant is registered, so a
+	// registered tenant always has its tables. A failed provisioning leaves
+	// an unregistered schema that the next attempt reuses.
+	if err := s.provisioner.Provision(ctx, tenant.SchemaName); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, tenant); err != nil {
+		return nil, err
+	}
+
+	return &tenant, nil
+}
+
+func (s *tenantService) GetAll(ctx context.Context) ([]dto.TenantDTO, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *tenantService) Resolve(ctx context.Context, slug string) (*dto.TenantDTO, error) {
+
+	slug = strings.ToLower(slug)
+	if !tenantSlugPattern.MatchString(slug) {
+		return nil, interfaces.ErrTenantNotFound
+	}
+
+	s.mu.RLock()
+	entry, ok := s.cache[slug]
+	s.mu.RUnlock()
+
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.tenant, nil
+	}
+
+	tenant, err := s.repo.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unknown slugs are not cached so arbitrary headers cannot grow the map.
+	if tenant == nil || tenant.Status != models.Active {
+		return nil, interfaces.ErrTenantNotFound
+	}
+
+	s.mu.Lock()
+	s.cache[slug] = cachedTenant{tenant: tenant, expiresAt: time.Now().Add(tenantCacheTTL)}
+	s.mu.Unlock()
+
+	return tenant, nil
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
+	"github.com/chand-magar/SolidBaseGoStructure/internal/models"
+)
+
+// fakeTenantRepo keeps tenants by slug and counts the lookups that reach it.
+type fakeTenantRepo struct {
+	tenants map[string]*dto.TenantDTO
+	lookups int
+}
+
+func (r *fakeTenantRepo) Create(ctx context.Context, data dto.TenantDTO) error {
+	if r.tenants == nil {
+		r.tenants = map[string]*dto.TenantDTO{}
+	}
+	r.tenants[data.Slug] = &data
+	return nil
+}
+
+func (r *fakeTenantRepo) GetAll(ctx context.Context) ([]dto.TenantDTO, error) {
+	return nil, nil
+}
+
+func (r *fakeTenantRepo) FindBySlug(ctx context.Context, slug string) (*dto.TenantDTO, error) {
+	r.lookups++
+	return r.tenants[slug], nil
+}
+
+func (r *fakeTenantRepo) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
+	_, ok := r.tenants[slug]
+	return ok, nil
+}
+
+// fakeProvisioner records the schemas it was asked to provision.
+type fakeProvisioner struct {
+	schemas []string
+	err     error
+}
+
+func (p *fakeProvisioner) Provision(ctx context.Context, schema string) error {
+	p.schemas = append(p.schemas, schema)
+	return p.err
+}
+
+func TestTenantResolve(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		slug    string
+		err     error
+		lookups int
+	}{
+		{"active tenant", "acme", nil, 1},
+		{"slug is case-insensitive", "ACME", nil, 1},
+		{"unknown tenant", "initech", interfaces.ErrTenantNotFound, 1},
+		{"inactive tenant", "globex", interfaces.ErrTenantNotFound, 1},
+		{"malformed slug skips the database", "acme.evil", interfaces.ErrTenantNotFound, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			repo := &fakeTenantRepo{tenants: map[string]*dto.TenantDTO{
+				"acme":   {Slug: "acme", SchemaName: "tenant_acme", Status: models.Active},
+				"globex": {Slug: "globex", SchemaName: "tenant_globex", Status: models.Inactive},
+			}}
+			service := NewTenantService(repo, &fakeProvisioner{})
+
+			tenant, err := service.Resolve(context.Background(), tt.slug)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Resolve() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && tenant.SchemaName != "tenant_acme" {
+				t.Errorf("Resolve() schema = %q", tenant.SchemaName)
+			}
+
+			// Only active tenants are cached; the rest reach the database
+			// every time.
+			service.Resolve(context.Background(), tt.slug)
+
+			lookups := tt.lookups
+			if tt.err != nil {
+				lookups *= 2
+			}
+			if repo.lookups != lookups {
+				t.Errorf("repository read %d times, want %d", repo.lookups, lookups)
+			}
+		})
+	}
+}
+
+func TestTenantResolveCacheExpires(t *testing.T) {
+
+	repo := &fakeTenantRepo{tenants: map[string]*dto.TenantDTO{
+		"acme": {Slug: "acme", SchemaName: "tenant_acme", Status: models.Active},
+	}}
+	service := NewTenantService(repo, &fakeProvisioner{}).(*tenantService)
+
+	if _, err := service.Resolve(context.Background(), "acme"); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	repo.tenants["acme"].Status = models.Inactive
+
+	if _, err := service.Resolve(context.Background(), "acme"); err != nil {
+		t.Errorf("cached tenant stopped resolving before it expired: %v", err)
+	}
+
+	service.mu.Lock()
+	entry := service.cache["acme"]
+	entry.expiresAt = time.Now().Add(-time.Second)
+	service.cache["acme"] = entry
+	service.mu.Unlock()
+
+	if _, err := service.Resolve(context.Background(), "acme"); !errors.Is(err, interfaces.ErrTenantNotFound) {
+		t.Errorf("deactivated tenant after expiry: error = %v, want %v", err, interfaces.ErrTenantNotFound)
+	}
+}
+
+func TestTenantCreate(t *testing.T) {
+
+	failure := errors.New("permission denied for database")
+
+	tests := []struct {
+		name      string
+		slug      string
+		provision error
+		err       error
+		schema    string
+	}{
+		{"hyphens become underscores", " Big-Co ", nil, nil, "tenant_big_co"},
+		{"slug too short", "a", nil, interfaces.ErrInvalidTenantSlug, ""},
+		{"slug starting with a digit", "1acme", nil, interfaces.ErrInvalidTenantSlug, ""},
+		{"slug too long", "abcdefghijklmnopqrstu", nil, interfaces.ErrInvalidTenantSlug, ""},
+		{"slug taken", "acme", nil, interfaces.ErrTenantSlugTaken, ""},
+		{"provisioning fails", "globex", failure, failure, "tenant_globex"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			repo := &fakeTenantRepo{tenants: map[string]*dto.TenantDTO{"acme": {Slug: "acme"}}}
+			provisioner := &fakeProvisioner{err: tt.provision}
+			service := NewTenantService(repo, provisioner)
+
+			tenant, err := service.Create(context.Background(), dto.TenantRequestDTO{Slug: tt.slug, TenantName: "Tenant"})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.err)
+			}
+
+			if tt.schema == "" {
+				if len(provisioner.schemas) != 0 {
+					t.Errorf("provisioned %v for a rejected tenant", provisioner.schemas)
+				}
+				return
+			}
+
+			if len(provisioner.schemas) != 1 || provisioner.schemas[0] != tt.schema {
+				t.Errorf("provisioned %v, want [%s]", provisioner.schemas, tt.schema)
+			}
+
+			registered := len(repo.tenants) == 2
+			if registered != (tt.err == nil) {
+				t.Errorf("tenant registered = %v after Create() error = %v", registered, err)
+			}
+			if err == nil && (tenant.SchemaName != tt.schema || tenant.Status != models.Active) {
+				t.Errorf("Create() = %+v", tenant)
+			}
+		})
+	}
+}
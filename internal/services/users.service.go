@@ -1,44 +1,64 @@
 package services
 
 import (
-	"fmt"
-	"math"
+	"context"
+	"time"
 
+	"github.com/chand-magar/SolidBaseGoStructure/internal/apperrors"
 	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
 	"github.com/chand-magar/SolidBaseGoStructure/internal/interfaces"
 	"github.com/google/uuid"
 )
 
 type userService struct {
-	repo interfaces.UserRepository
+	repo           interfaces.UserRepository
+	purgeRetention time.Duration
 }
 
-func NewUserService(repo interfaces.UserRepository) interfaces.UserService {
-	return &userService{repo: repo}
+func NewUserService(repo interfaces.UserRepository, purgeRetention time.Duration) interfaces.UserService {
+	return &userService{repo: repo, purgeRetention: purgeRetention}
 }
 
-func (s *userService) Create(data dto.RequestDTO) (uuid.UUID, error) {
+func (s *userService) Create(ctx context.Context, data dto.RequestDTO) (uuid.UUID, error) {
 
 	if data.UserFullName == "" {
-		return uuid.Nil, fmt.Errorf("User full name is required")
+		return uuid.Nil, apperrors.New(apperrors.Validation, "User full name is required")
 	}
-	return s.repo.Create(data)
+	return s.repo.Create(ctx, data)
 }
 
-func (s *userService) GetAll(params dto.PaginationParams) ([]dto.ResponseDTO, int64, int, error) {
-	users, totalRecords, err := s.repo.GetAll(params)
-	if err != nil {
-		return nil, 0, 0, err
-	}
+func (s *userService) GetAll(ctx context.Context, params dto.PaginationParams) (dto.PageResult[dto.ResponseDTO], error) {
+	return s.repo.List(ctx, params)
+}
+
+func (s *userService) GetPage(ctx context.Context, params dto.PaginationParams) ([]dto.ResponseDTO, *dto.CursorPage, error) {
+	return s.repo.GetPage(ctx, params)
+}
+
+func (s *userService) FindOne(ctx context.Context, id uuid.UUID) (*dto.ResponseDTO, error) {
+	return s.repo.FindOne(ctx, id)
+}
 
-	totalPages := int(math.Ceil(float64(totalRecords) / float64(params.Size)))
-	return users, totalRecords, totalPages, nil
+func (s *userService) Update(ctx context.Context, id uuid.UUID, data dto.RequestDTO) error {
+	return s.repo.Update(ctx, id, data)
 }
 
-func (s *userService) FindOne(id uuid.UUID) (*dto.ResponseDTO, error) {
-	return s.repo.FindOne(id)
+func (s *userService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.SoftDelete(ctx, id)
+}
+
+func (s *userService) Restore(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Restore(ctx, id)
+}
+
+func (s *userService) ResetPassword(ctx context.Context, username, password string) error {
+
+	if password == "" {
+		return apperrors.New(apperrors.Validation, "Password is required")
+	}
+	return s.repo.ResetPassword(ctx, username, password)
 }
 
-func (s *userService) Update(id uuid.UUID, data dto.RequestDTO) error {
-	return s.repo.Update(id, data)
+func (s *userService) Purge(ctx context.Context) (int64, error) {
+	return s.repo.PurgeDeletedBefore(ctx, time.Now().UTC().Add(-s.purgeRetention))
 }
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+)
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated principal.
+func WithPrincipal(ctx context.Context, principal *dto.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated principal stored in ctx, if any.
+func PrincipalFromContext(ctx context.Context) (*dto.Principal, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	principal, ok := ctx.Value(principalKey{}).(*dto.Principal)
+	return principal, ok && principal != nil
+}
+
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx scoped to tenant. Without a tenant,
+// queries use the default (master) schema.
+func WithTenant(ctx context.Context, tenant *dto.TenantDTO) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant the request was resolved to, if any.
+func TenantFromContext(ctx context.Context) (*dto.TenantDTO, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	tenant, ok := ctx.Value(tenantKey{}).(*dto.TenantDTO)
+	return tenant, ok && tenant != nil
+}
+
+type requestIdKey struct{}
+
+// WithRequestId returns a copy of ctx carrying the request's correlation ID.
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// RequestIdFromContext returns the request's correlation ID, or "" outside
+// of a request.
+func RequestIdFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
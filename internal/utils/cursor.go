@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+)
+
+// EncodeCursor returns the opaque form of cursor handed to clients.
+func EncodeCursor(cursor dto.Cursor) string {
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor reverses EncodeCursor. The values are only checked for
+// shape; the listing that issued the cursor validates them.
+func DecodeCursor(value string) (*dto.Cursor, error) {
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	var cursor dto.Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || len(cursor.Values) == 0 {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	return &cursor, nil
+}
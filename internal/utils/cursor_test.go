@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+
+	tests := []dto.Cursor{
+		{Sort: "user_full_name", Values: json.RawMessage(`["Ann"]`), Id: 7},
+		{Sort: "-created_at,dob", Values: json.RawMessage(`["2024-03-01T08:30:15.123456Z","1990-07-14"]`), Id: 1 << 40, Before: true},
+		{Sort: "email_id", Values: json.RawMessage(`["a/b+c=d@example.com"]`), Id: 3},
+	}
+
+	for _, cursor := range tests {
+		t.Run(cursor.Sort, func(t *testing.T) {
+
+			decoded, err := DecodeCursor(EncodeCursor(cursor))
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if !reflect.DeepEqual(*decoded, cursor) {
+				t.Errorf("DecodeCursor() = %+v, want %+v", *decoded, cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejectsMalformed(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"empty", ""},
+		{"not base64", "not a cursor!"},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"s":"x","v":["a"],"id":1}`))},
+		{"not json", base64.RawURLEncoding.EncodeToString([]byte(`cursor`))},
+		{"no values", base64.RawURLEncoding.EncodeToString([]byte(`{"s":"x","id":1}`))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if cursor, err := DecodeCursor(tt.value); err == nil {
+				t.Errorf("DecodeCursor(%q) = %+v, want an error", tt.value, cursor)
+			}
+		})
+	}
+}
@@ -1,16 +1,19 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"regexp"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
-var jwtKey = []byte("HJGJH!a`#@!-@-`~@12-901asdAZw")
-
 func HashPassword(password string) (string, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -24,16 +27,43 @@ func CheckPassword(hashedPassword, password string) bool {
 	return err == nil
 }
 
-func CreateToken(userClaims []map[string]interface{}) (string, error) {
+// UserClaims is the payload carried by every access token.
+type UserClaims struct {
+	ProfileNo uint32    `json:"profile_no"`
+	ProfileId uuid.UUID `json:"profile_id"`
+	RoleId    uuid.UUID `json:"role_id"`
+	Role      string    `json:"role"`
+	// Tenant is the slug of the tenant the token was issued in; empty for
+	// the default tenant.
+	Tenant string `json:"tenant,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Token lifetimes, overridden from config by InitJWT.
+var (
+	AccessTokenTTL  = time.Minute * 15
+	RefreshTokenTTL = time.Hour * 24 * 30
+)
+
+func CreateToken(claims UserClaims) (string, error) {
+
+	if signingKey == nil {
+		return "", fmt.Errorf("jwt signing key is not configured")
+	}
+
+	now := time.Now()
 
-	token := jwt.NewWithClaims(
-		jwt.SigningMethodHS256,
-		jwt.MapClaims{
-			"userClaims": userClaims,
-			"exp":        time.Now().Add(time.Hour * 24).Unix(),
-		})
+	claims.Issuer = tokenIssuer
+	claims.Audience = jwt.ClaimStrings{tokenAudience}
+	claims.Subject = claims.ProfileId.String()
+	claims.ID = uuid.NewString()
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(AccessTokenTTL))
 
-	tokenString, err := token.SignedString(jwtKey)
+	token := jwt.NewWithClaims(signingKey.method, claims)
+	token.Header["kid"] = signingKey.kid
+
+	tokenString, err := token.SignedString(signingKey.private)
 	if err != nil {
 		return "", err
 	}
@@ -41,21 +71,71 @@ func CreateToken(userClaims []map[string]interface{}) (string, error) {
 	return tokenString, nil
 }
 
-func VerifyToken(tokenString string) error {
+// VerifyToken checks the signature, expiry, issuer and audience of the token
+// and returns its claims.
+func VerifyToken(tokenString string) (*UserClaims, error) {
+
+	claims := &UserClaims{}
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return jwtKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, lookupVerificationKey,
+		jwt.WithValidMethods(validMethods),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithAudience(tokenAudience),
+		jwt.WithExpirationRequired(),
+	)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !token.Valid {
-		return fmt.Errorf("invalid token")
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	return claims, nil
+}
+
+// TenantClaim reads the tenant claim of a token without verifying it. It
+// only picks the schema the token is then verified against.
+func TenantClaim(tokenString string) string {
+
+	claims := &UserClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tokenString, claims); err != nil {
+		return ""
+	}
+
+	return claims.Tenant
+}
+
+// GenerateRefreshToken returns a random opaque refresh token together with
+// the hash that is persisted in its place.
+func GenerateRefreshToken() (string, string, error) {
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	return token, HashRefreshToken(token), nil
+}
+
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GeneratePassword returns a random 24 character URL-safe password for
+// accounts created without one.
+func GeneratePassword() (string, error) {
+
+	buf := make([]byte, 18)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
 	}
 
-	return nil
+	return base64.RawURLEncoding.EncodeToString(buf), nil
 }
 
 func isValidEmail(email string) bool {
@@ -0,0 +1,231 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type jwtKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private interface{}
+	public  interface{}
+}
+
+// JWK is the public form of a verification key as published in the JWKS.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+var (
+	signingKey       *jwtKey
+	verificationKeys = map[string]*jwtKey{}
+	validMethods     []string
+
+	tokenIssuer   string
+	tokenAudience string
+)
+
+// InitJWT loads the configured keys and token settings. It must be called
+// before any token is created or verified.
+func InitJWT(cfg config.JWT) error {
+
+	if len(cfg.Keys) == 0 {
+		return fmt.Errorf("jwt: no signing keys configured")
+	}
+
+	keys := make(map[string]*jwtKey, len(cfg.Keys))
+	methods := []string{}
+
+	for _, keyCfg := range cfg.Keys {
+		key, err := loadKey(keyCfg)
+		if err != nil {
+			return fmt.Errorf("jwt: key %q: %w", keyCfg.Kid, err)
+		}
+		if _, exists := keys[key.kid]; exists {
+			return fmt.Errorf("jwt: duplicate kid %q", key.kid)
+		}
+		keys[key.kid] = key
+		methods = append(methods, key.method.Alg())
+	}
+
+	signing, ok := keys[cfg.SigningKid]
+	if !ok {
+		return fmt.Errorf("jwt: signing kid %q is not among the configured keys", cfg.SigningKid)
+	}
+	if signing.private == nil {
+		return fmt.Errorf("jwt: signing key %q has no private key", cfg.SigningKid)
+	}
+
+	signingKey = signing
+	verificationKeys = keys
+	validMethods = methods
+
+	tokenIssuer = cfg.Issuer
+	tokenAudience = cfg.Audience
+	AccessTokenTTL = cfg.AccessTokenTTL
+	RefreshTokenTTL = cfg.RefreshTokenTTL
+
+	return nil
+}
+
+func loadKey(cfg config.SigningKey) (*jwtKey, error) {
+
+	if cfg.Kid == "" {
+		return nil, fmt.Errorf("kid is required")
+	}
+
+	key := &jwtKey{kid: cfg.Kid}
+
+	switch strings.ToUpper(cfg.Algorithm) {
+	case "HS256":
+		secret := []byte(cfg.Secret)
+		if cfg.SecretFile != "" {
+			data, err := os.ReadFile(cfg.SecretFile)
+			if err != nil {
+				return nil, err
+			}
+			secret = []byte(strings.TrimSpace(string(data)))
+		}
+		if len(secret) < 32 {
+			return nil, fmt.Errorf("HS256 secret must be at least 32 bytes")
+		}
+		key.method = jwt.SigningMethodHS256
+		key.private = secret
+		key.public = secret
+
+	case "RS256":
+		key.method = jwt.SigningMethodRS256
+		if cfg.PrivateKeyFile != "" {
+			data, err := os.ReadFile(cfg.PrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			private, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			key.private = private
+			key.public = &private.PublicKey
+		} else if cfg.PublicKeyFile != "" {
+			data, err := os.ReadFile(cfg.PublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			public, err := jwt.ParseRSAPublicKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			key.public = public
+		}
+
+	case "EDDSA":
+		key.method = jwt.SigningMethodEdDSA
+		if cfg.PrivateKeyFile != "" {
+			data, err := os.ReadFile(cfg.PrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			private, err := jwt.ParseEdPrivateKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			key.private = private
+			key.public = private.(ed25519.PrivateKey).Public()
+		} else if cfg.PublicKeyFile != "" {
+			data, err := os.ReadFile(cfg.PublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			public, err := jwt.ParseEdPublicKeyFromPEM(data)
+			if err != nil {
+				return nil, err
+			}
+			key.public = public
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", cfg.Algorithm)
+	}
+
+	if key.public == nil {
+		return nil, fmt.Errorf("private_key_file or public_key_file is required")
+	}
+
+	return key, nil
+}
+
+func lookupVerificationKey(token *jwt.Token) (interface{}, error) {
+
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := verificationKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+
+	return key.public, nil
+}
+
+// PublicJWKS returns the asymmetric verification keys in JWKS form. HS256
+// secrets are never published.
+func PublicJWKS() JWKSet {
+
+	set := JWKSet{Keys: []JWK{}}
+
+	kids := make([]string, 0, len(verificationKeys))
+	for kid := range verificationKeys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	for _, kid := range kids {
+		key := verificationKeys[kid]
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA",
+				Kid: key.kid,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "OKP",
+				Kid: key.kid,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(public),
+			})
+		}
+	}
+
+	return set
+}
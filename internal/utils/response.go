@@ -1,51 +1,48 @@
 package utils
 
 import (
-	"encoding/json"
-	"fmt"
 	"net/http"
 
-	"github.com/go-playground/validator/v10"
+	"github.com/gin-gonic/gin"
 )
 
-type Response struct {
-	Status string `json:"status"`
-	Error  string `json:"error"`
+// ProblemContentType is the media type of every error response.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body. Type is "about:blank", so
+// Title is the HTTP status text and Detail says what went wrong.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestId string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
 }
 
-const (
-	StatusOK    = "OK"
-	StatusError = "Error"
-)
-
-func WriteJson(w http.ResponseWriter, status int, data interface{}) error {
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-
-	return json.NewEncoder(w).Encode(data)
+// FieldError describes one invalid field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
-func GeneralError(err error) Response {
-	return Response{
-		Status: StatusError,
-		Error:  err.Error(),
+// AbortWithProblem stops the request and answers it with a problem for
+// status. Instance is the request path and RequestId the ID set by the
+// RequestID middleware.
+func AbortWithProblem(c *gin.Context, status int, detail string, errs ...FieldError) {
+
+	problem := Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		RequestId: RequestIdFromContext(c.Request.Context()),
+		Errors:    errs,
 	}
-}
 
-func ValidationError(errs validator.ValidationErrors) Response {
-	var errMsg string
-
-	firstErr := errs[0]
-	switch firstErr.ActualTag() {
-	case "required":
-		errMsg = fmt.Sprintf("field %s is required", firstErr.Field())
-	default:
-		errMsg = fmt.Sprintf("field %s is invalid", firstErr.Field())
-	}
-
-	return Response{
-		Status: StatusError,
-		Error:  errMsg,
-	}
+	// c.JSON keeps a Content-Type that is already set.
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(status, problem)
 }
@@ -0,0 +1,301 @@
+package utils
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/de"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/fr"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	de_translations "github.com/go-playground/validator/v10/translations/de"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	es_translations "github.com/go-playground/validator/v10/translations/es"
+	fr_translations "github.com/go-playground/validator/v10/translations/fr"
+)
+
+// updateTag holds the rules a field is checked against on partial updates,
+// where it replaces the create-time `validate` tag.
+const updateTag = "update"
+
+// Update twins of the request DTOs shared by create and update endpoints.
+// They have the same fields, but their update tags are registered as rules,
+// which take the place of the validate tags.
+type (
+	userUpdate    dto.RequestDTO
+	roleUpdate    dto.RoleRequestDTO
+	sectionUpdate dto.SectionRequestDTO
+	pageUpdate    dto.PageRequestDTO
+)
+
+type validatorTranslations struct {
+	locale   locales.Translator
+	register func(*validator.Validate, ut.Translator) error
+}
+
+// supportedTranslations lists the languages error messages are offered in;
+// the first one is the fallback.
+var supportedTranslations = []validatorTranslations{
+	{en.New(), en_translations.RegisterDefaultTranslations},
+	{es.New(), es_translations.RegisterDefaultTranslations},
+	{fr.New(), fr_translations.RegisterDefaultTranslations},
+	{de.New(), de_translations.RegisterDefaultTranslations},
+}
+
+var (
+	translators = newTranslators()
+	validate    = newValidator(userUpdate{}, roleUpdate{}, sectionUpdate{}, pageUpdate{})
+)
+
+func newTranslators() *ut.UniversalTranslator {
+
+	all := make([]locales.Translator, 0, len(supportedTranslations))
+	for _, t := range supportedTranslations {
+		all = append(all, t.locale)
+	}
+
+	return ut.New(all[0], all...)
+}
+
+// newValidator returns a validator that names fields by their JSON name and
+// has messages for every supported language. Fields of updateTypes that
+// carry an update tag are checked against it instead of their validate tag.
+func newValidator(updateTypes ...interface{}) *validator.Validate {
+
+	v := validator.New()
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	for _, t := range supportedTranslations {
+		trans, _ := translators.GetTranslator(t.locale.Locale())
+		if err := t.register(v, trans); err != nil {
+			panic("validation: " + t.locale.Locale() + " translations: " + err.Error())
+		}
+	}
+
+	for _, value := range updateTypes {
+		typ := reflect.TypeOf(value)
+		rules := map[string]string{}
+		for i := 0; i < typ.NumField(); i++ {
+			if rule, ok := typ.Field(i).Tag.Lookup(updateTag); ok {
+				rules[typ.Field(i).Name] = rule
+			}
+		}
+		v.RegisterStructValidationMapRules(rules, value)
+	}
+
+	return v
+}
+
+// Validate checks a request against its validate tags.
+func Validate(request interface{}) error {
+	return validate.Struct(request)
+}
+
+// ValidateUpdate checks a partial update: fields with an update tag use it
+// in place of their validate tag, which usually makes them optional while
+// keeping their format and length rules.
+func ValidateUpdate(request interface{}) error {
+
+	switch r := request.(type) {
+	case dto.RequestDTO:
+		return validate.Struct(userUpdate(r))
+	case dto.RoleRequestDTO:
+		return validate.Struct(roleUpdate(r))
+	case dto.SectionRequestDTO:
+		return validate.Struct(sectionUpdate(r))
+	case dto.PageRequestDTO:
+		return validate.Struct(pageUpdate(r))
+	}
+
+	return validate.Struct(request)
+}
+
+// Translator picks the best supported language for an Accept-Language
+// header, falling back to English.
+func Translator(acceptLanguage string) ut.Translator {
+	trans, _ := translators.FindTranslator(acceptedLocales(acceptLanguage)...)
+	return trans
+}
+
+// AbortWithValidationProblem answers a request body that failed to bind or
+// a failed Validate or ValidateUpdate with a 400 problem listing every
+// invalid field, in the language the client asked for. Decoding errors are
+// reported without echoing the decoder's message.
+func AbortWithValidationProblem(c *gin.Context, err error) {
+
+	var invalidErr *validator.InvalidValidationError
+	if errors.As(err, &invalidErr) {
+		// Not a client error: the request value could not be validated.
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		AbortWithProblem(c, http.StatusBadRequest, "Request body is invalid", bindErrors(err)...)
+		return
+	}
+
+	trans := Translator(c.GetHeader("Accept-Language"))
+	c.Header("Content-Language", strings.ReplaceAll(trans.Locale(), "_", "-"))
+
+	AbortWithProblem(c, http.StatusBadRequest, "Request validation failed", ValidationErrors(validationErrs, trans)...)
+}
+
+// bindErrors names the field a JSON body could not be decoded into, in the
+// path form ValidationErrors uses. Syntax errors, empty bodies and values the
+// field's own decoder rejected name no field.
+func bindErrors(err error) []FieldError {
+
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Field == "" {
+		return nil
+	}
+
+	return []FieldError{{Field: jsonFieldPath(typeErr.Field), Message: "must be " + jsonKind(typeErr.Type)}}
+}
+
+// jsonFieldPath turns the dotted path of encoding/json, e.g. items.1.order,
+// into items[1].order.
+func jsonFieldPath(path string) string {
+
+	var b strings.Builder
+
+	for i, part := range strings.Split(path, ".") {
+		switch {
+		case isIndex(part):
+			b.WriteString("[" + part + "]")
+		case i > 0:
+			b.WriteString("." + part)
+		default:
+			b.WriteString(part)
+		}
+	}
+
+	return b.String()
+}
+
+func isIndex(part string) bool {
+	_, err := strconv.Atoi(part)
+	return err == nil
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// jsonKind describes the JSON value a field of type t is decoded from.
+func jsonKind(t reflect.Type) string {
+
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return "a string"
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return jsonKind(t.Elem())
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := t.Bits()
+		return fmt.Sprintf("an integer from %d to %d", int64(-1)<<(bits-1), int64(math.MaxInt64>>(64-bits)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("an integer from 0 to %d", uint64(math.MaxUint64>>(64-t.Bits())))
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+// ValidationErrors lists every failed validation as a FieldError named by
+// its JSON path, e.g. role_details[0].page_path. Messages missing from
+// trans are given in the fallback language.
+func ValidationErrors(errs validator.ValidationErrors, trans ut.Translator) []FieldError {
+
+	fallback := translators.GetFallback()
+	fields := make([]FieldError, 0, len(errs))
+
+	for _, err := range errs {
+		message := err.Translate(trans)
+		if message == err.Error() {
+			message = err.Translate(fallback)
+		}
+
+		// The namespace starts with the Go name of the validated struct.
+		_, field, _ := strings.Cut(err.Namespace(), ".")
+
+		fields = append(fields, FieldError{Field: field, Message: message})
+	}
+
+	return fields
+}
+
+// acceptedLocales turns an Accept-Language header into locale names, most
+// preferred first; each regional tag is followed by its base language.
+func acceptedLocales(header string) []string {
+
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+
+	var accepted []weighted
+
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, err := strconv.ParseFloat(value, 64)
+			if err != nil || q <= 0 {
+				continue
+			}
+			quality = q
+		}
+
+		locale := strings.ReplaceAll(tag, "-", "_")
+		accepted = append(accepted, weighted{locale, quality})
+		if base, _, regional := strings.Cut(locale, "_"); regional {
+			accepted = append(accepted, weighted{base, quality})
+		}
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].quality > accepted[j].quality
+	})
+
+	names := make([]string, 0, len(accepted))
+	for _, a := range accepted {
+		names = append(names, a.locale)
+	}
+
+	return names
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chand-magar/SolidBaseGoStructure/internal/dto"
+	"github.com/gin-gonic/gin"
+)
+
+func TestAcceptedLocales(t *testing.T) {
+
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{"", []string{}},
+		{"*", []string{}},
+		{"fr", []string{"fr"}},
+		{"es-MX", []string{"es_MX", "es"}},
+		{"de;q=0.5, fr;q=0.9", []string{"fr", "de"}},
+		{"en-GB,en;q=0.8,de;q=0.9", []string{"en_GB", "en", "de", "en"}},
+		{"fr;q=0.7, es;q=0.7, de", []string{"de", "fr", "es"}},
+		{" fr-CA ; q=0.4 , *;q=0.1 ", []string{"fr_CA", "fr"}},
+		{"fr;q=0, de", []string{"de"}},
+		{"fr;q=abc, es", []string{"es"}},
+		{"es;level=1", []string{"es"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := acceptedLocales(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("acceptedLocales(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslator(t *testing.T) {
+
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", "en"},
+		{"ja", "en"},
+		{"es-MX", "es"},
+		{"ja, de;q=0.3", "de"},
+		{"fr;q=0.2, es;q=0.8", "es"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := Translator(tt.header).Locale(); got != tt.want {
+				t.Errorf("Translator(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAbortWithValidationProblemOnBindErrors(t *testing.T) {
+
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		body   string
+		detail string
+		errors []FieldError
+	}{
+		{
+			name:   "wrong type in a list",
+			body:   `{"items": [{"id": "6f1c7a52-4c1e-4d8e-9a51-0c5b8c9d1e2f", "order": 1}, {"order": "x"}]}`,
+			detail: "Request body is invalid",
+			errors: []FieldError{{Field: "items[1].order", Message: "must be an integer from 0 to 255"}},
+		},
+		{
+			name:   "number out of range",
+			body:   `{"items": [{"order": 300}]}`,
+			detail: "Request body is invalid",
+			errors: []FieldError{{Field: "items[0].order", Message: "must be an integer from 0 to 255"}},
+		},
+		{
+			name:   "text field given a number",
+			body:   `{"items": [{"id": 7}]}`,
+			detail: "Request body is invalid",
+			errors: []FieldError{{Field: "items[0].id", Message: "must be a string"}},
+		},
+		{name: "syntax error", body: `{"items": [`, detail: "Request body is invalid"},
+		{name: "empty body", body: ``, detail: "Request body is invalid"},
+		{name: "not an object", body: `[]`, detail: "Request body is invalid"},
+		{name: "value rejected by its decoder", body: `{"items": [{"id": "not-a-uuid"}]}`, detail: "Request body is invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			router := gin.New()
+			router.POST("/", func(c *gin.Context) {
+				var request dto.ReorderDTO
+				if err := c.ShouldBindJSON(&request); err != nil {
+					AbortWithValidationProblem(c, err)
+				}
+			})
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body)))
+
+			if recorder.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", recorder.Code)
+			}
+
+			var problem Problem
+			if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("body is not a problem: %v", err)
+			}
+			if problem.Detail != tt.detail {
+				t.Errorf("detail = %q, want %q", problem.Detail, tt.detail)
+			}
+			if !reflect.DeepEqual(problem.Errors, tt.errors) {
+				t.Errorf("errors = %+v, want %+v", problem.Errors, tt.errors)
+			}
+		})
+	}
+}